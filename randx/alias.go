@@ -0,0 +1,124 @@
+package randx
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+)
+
+// Alias draws indices in [0, n) with probability proportional to the weights
+// it was built from, in O(1) time per draw (Vose's alias method). The table
+// is read-only after construction and safe for concurrent use.
+type Alias struct {
+	prob  []float64
+	alias []int
+}
+
+// NewAlias builds the alias table for weights in O(n). Weights need not be
+// normalized but must be finite, non-negative and not all zero.
+func NewAlias(weights []float64) (*Alias, error) {
+	n := len(weights)
+	if n == 0 {
+		return nil, errors.New("randx: alias: no weights")
+	}
+	sum := 0.0
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return nil, errors.New("randx: alias: weights must be finite and non-negative")
+		}
+		sum += w
+	}
+	if sum == 0 {
+		return nil, errors.New("randx: alias: weights sum to zero")
+	}
+
+	a := &Alias{
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+	scaled := make([]float64, n)
+	small := make([]int, 0, n)
+	large := make([]int, 0, n)
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / sum
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		large = large[:len(large)-1]
+
+		a.prob[s] = scaled[s]
+		a.alias[s] = l
+		scaled[l] = (scaled[l] + scaled[s]) - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	// Whatever is left is 1 up to rounding error.
+	for _, i := range large {
+		a.prob[i] = 1
+		a.alias[i] = i
+	}
+	for _, i := range small {
+		a.prob[i] = 1
+		a.alias[i] = i
+	}
+	return a, nil
+}
+
+// Len returns the number of outcomes.
+func (a *Alias) Len() int {
+	return len(a.prob)
+}
+
+func (a *Alias) Rand() int {
+	return a.RandWith(nil)
+}
+
+// RandWith draws an index using r, or the package-level generator when r is
+// nil. A single uniform picks both the column and the coin flip.
+func (a *Alias) RandWith(r *rand.Rand) int {
+	u := uniform(r) * float64(len(a.prob))
+	i := int(u)
+	if u-float64(i) < a.prob[i] {
+		return i
+	}
+	return a.alias[i]
+}
+
+// AliasOf draws items of type T with probability proportional to their
+// weights, using an Alias table over their indices.
+type AliasOf[T any] struct {
+	items []T
+	table *Alias
+}
+
+// NewAliasOf builds a weighted sampler over items; weights[i] is the weight of
+// items[i].
+func NewAliasOf[T any](items []T, weights []float64) (*AliasOf[T], error) {
+	if len(items) != len(weights) {
+		return nil, errors.New("randx: alias: items and weights differ in length")
+	}
+	table, err := NewAlias(weights)
+	if err != nil {
+		return nil, err
+	}
+	return &AliasOf[T]{items: items, table: table}, nil
+}
+
+func (a *AliasOf[T]) Rand() T {
+	return a.RandWith(nil)
+}
+
+func (a *AliasOf[T]) RandWith(r *rand.Rand) T {
+	return a.items[a.table.RandWith(r)]
+}
@@ -0,0 +1,92 @@
+package randx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAlias_MatchesWeights(t *testing.T) {
+	weights := []float64{1, 0, 3.5, 0.25, 10, 2, 0, 0.01}
+	a, err := NewAlias(weights)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	const draws = 500000
+	counts := make([]int, len(weights))
+	r := NewRand(4)
+	for range draws {
+		counts[a.RandWith(r)]++
+	}
+	total := 0.0
+	for _, w := range weights {
+		total += w
+	}
+	stat, bins := 0.0, 0
+	for i, w := range weights {
+		exp := draws * w / total
+		if exp == 0 {
+			if counts[i] != 0 {
+				t.Fatalf("index %d has zero weight but was drawn %d times", i, counts[i])
+			}
+			continue
+		}
+		d := float64(counts[i]) - exp
+		stat += d * d / exp
+		bins++
+	}
+	if crit := (Chi2Dist{K: float64(bins - 1)}).Quantile(1 - 1e-6); stat > crit {
+		t.Fatalf("chi-square %v exceeds %v", stat, crit)
+	}
+}
+
+func TestAlias_InvalidWeights(t *testing.T) {
+	for _, w := range [][]float64{nil, {0, 0}, {1, -1}, {1, math.NaN()}, {math.Inf(1)}} {
+		if _, err := NewAlias(w); err == nil {
+			t.Errorf("NewAlias(%v): expected error", w)
+		}
+	}
+	if _, err := NewAliasOf([]string{"a"}, []float64{1, 2}); err == nil {
+		t.Errorf("NewAliasOf: expected length mismatch error")
+	}
+}
+
+func TestAliasOf_ReturnsItems(t *testing.T) {
+	a, err := NewAliasOf([]string{"never", "always"}, []float64{0, 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := NewRand(1)
+	for range 1000 {
+		if got := a.RandWith(r); got != "always" {
+			t.Fatalf("got %q", got)
+		}
+	}
+}
+
+func BenchmarkAlias_1e4(b *testing.B) {
+	weights := make([]float64, 10000)
+	for i := range weights {
+		weights[i] = float64(i%97 + 1)
+	}
+	a, _ := NewAlias(weights)
+	r := NewRand(1)
+	var sink int
+	for b.Loop() {
+		sink += a.RandWith(r)
+	}
+	_ = sink
+}
+
+func BenchmarkCategorical_Rand_1e4(b *testing.B) {
+	weights := make([]float64, 10000)
+	for i := range weights {
+		weights[i] = float64(i%97 + 1)
+	}
+	c := CategoricalDist{Weights: weights}
+	r := NewRand(1)
+	var sink float64
+	for b.Loop() {
+		sink += c.RandWith(r)
+	}
+	_ = sink
+}
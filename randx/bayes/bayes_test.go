@@ -0,0 +1,231 @@
+package bayes
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/miguelm-revel/revelTools/randx"
+)
+
+func TestBetaBinomial(t *testing.T) {
+	m := BetaBinomial{Alpha: 1, Beta: 1}
+	if err := m.Update(12, 40); err != nil {
+		t.Fatal(err)
+	}
+	m.Update(3, 10)
+	for _, c := range [][2]int{{5, 4}, {-1, 3}, {0, -1}} {
+		var pe *randx.ParamError
+		if err := m.Update(c[0], c[1]); !errors.As(err, &pe) {
+			t.Errorf("Update(%d, %d) error = %v, want a ParamError", c[0], c[1], err)
+		}
+	}
+	if m.Alpha != 16 || m.Beta != 36 {
+		t.Fatalf("posterior = %+v, want Beta(16, 36)", m)
+	}
+	if got := m.Posterior().(randx.BetaDist); got.Alpha != 16 || got.Beta != 36 {
+		t.Errorf("Posterior() = %+v", got)
+	}
+	lo, hi := m.CredibleInterval(0.95)
+	post := randx.BetaDist{Alpha: 16, Beta: 36}
+	if math.Abs(post.CDF(hi)-post.CDF(lo)-0.95) > 1e-9 || math.Abs(post.CDF(lo)-0.025) > 1e-9 {
+		t.Errorf("credible interval [%v, %v]", lo, hi)
+	}
+	if lo, hi := m.CredibleInterval(1); !math.IsNaN(lo) || !math.IsNaN(hi) {
+		t.Error("level 1 should give NaN")
+	}
+
+	pred := m.Predictive(10).(BetaBinomialDist)
+	sum, mean, second := 0.0, 0.0, 0.0
+	for k := 0; k <= 10; k++ {
+		p := pred.PDF(float64(k))
+		sum += p
+		mean += float64(k) * p
+		second += float64(k*k) * p
+	}
+	if math.Abs(sum-1) > 1e-12 || math.Abs(mean-pred.Mean()) > 1e-12 ||
+		math.Abs(second-mean*mean-pred.Variance()) > 1e-10 {
+		t.Errorf("predictive pmf sums to %v with mean %v, variance %v; want %v, %v",
+			sum, mean, second-mean*mean, pred.Mean(), pred.Variance())
+	}
+	if d, err := randx.ParseDist("betabinomial(10, 16, 36)"); err != nil || d != pred {
+		t.Errorf("ParseDist = %#v, %v; want %#v", d, err, pred)
+	}
+	data, err := json.Marshal(pred)
+	if err != nil || string(data) != `{"type":"betabinomial","n":10,"alpha":16,"beta":36}` {
+		t.Errorf("json.Marshal = %s, %v", data, err)
+	}
+	var back BetaBinomialDist
+	if err := json.Unmarshal(data, &back); err != nil || back != pred {
+		t.Errorf("json.Unmarshal(%s) = %#v, %v", data, back, err)
+	}
+	if err := json.Unmarshal([]byte(`{"n":10,"alpha":-1,"beta":36}`), &back); err == nil {
+		t.Error("expected an error for an invalid Alpha")
+	}
+	if q := pred.Quantile(pred.CDF(4)); q != 4 {
+		t.Errorf("Quantile(CDF(4)) = %v", q)
+	}
+	// Beta(1, 1) mixing makes every count equally likely.
+	if p := (BetaBinomialDist{N: 4, Alpha: 1, Beta: 1}).PDF(2); math.Abs(p-0.2) > 1e-12 {
+		t.Errorf("uniform beta-binomial PDF(2) = %v, want 0.2", p)
+	}
+	r := randx.NewRand(1)
+	total := 0.0
+	for range 20000 {
+		total += pred.RandWith(r)
+	}
+	if got := total / 20000; math.Abs(got-pred.Mean()) > 5*math.Sqrt(pred.Variance()/20000) {
+		t.Errorf("sample mean %v, want ≈ %v", got, pred.Mean())
+	}
+}
+
+func TestGammaPoisson(t *testing.T) {
+	m := GammaPoisson{Shape: 2, Rate: 1}
+	if err := m.Update(3, 5, 4); err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []float64{-1, 2.5, math.NaN(), math.Inf(1)} {
+		if err := m.Update(1, k); !errors.Is(err, randx.ErrInvalidParameter) {
+			t.Errorf("Update(1, %v) error = %v, want ErrInvalidParameter", k, err)
+		}
+	}
+	if m.Shape != 14 || m.Rate != 4 {
+		t.Fatalf("posterior = %+v, want Gamma(14, 4)", m)
+	}
+	if got := m.Posterior().(randx.GammaDist).Mean(); math.Abs(got-3.5) > 1e-12 {
+		t.Errorf("posterior mean = %v, want 3.5", got)
+	}
+	// The negative binomial predictive has mean a/b and variance a(b+1)/b².
+	pred := m.Predictive().(randx.NegBinomialDist)
+	if math.Abs(pred.Mean()-3.5) > 1e-12 || math.Abs(pred.Variance()-14*5.0/16) > 1e-12 {
+		t.Errorf("predictive mean %v variance %v", pred.Mean(), pred.Variance())
+	}
+	lo, hi := m.CredibleInterval(0.9)
+	if !(lo < 3.5 && 3.5 < hi) {
+		t.Errorf("credible interval [%v, %v] misses the mean", lo, hi)
+	}
+}
+
+func TestNormalNormal(t *testing.T) {
+	m := NormalNormal{Mu: 0, Var: 4, NoiseVar: 1}
+	if err := m.Update(1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	for _, x := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if err := m.Update(1, x); !errors.Is(err, randx.ErrInvalidParameter) {
+			t.Errorf("Update(1, %v) error = %v, want ErrInvalidParameter", x, err)
+		}
+	}
+	// Precision 1/4 + 3 = 13/4, mean (4/13)·6.
+	if math.Abs(m.Var-4.0/13) > 1e-15 || math.Abs(m.Mu-24.0/13) > 1e-15 {
+		t.Fatalf("posterior = %+v", m)
+	}
+	if got := m.Predictive().(randx.NormalDist).Variance(); math.Abs(got-(1+4.0/13)) > 1e-12 {
+		t.Errorf("predictive variance = %v", got)
+	}
+	lo, hi := m.CredibleInterval(0.95)
+	if half := 1.959963984540054 * math.Sqrt(4.0/13); math.Abs(hi-m.Mu-half) > 1e-9 || math.Abs(m.Mu-lo-half) > 1e-9 {
+		t.Errorf("credible interval [%v, %v]", lo, hi)
+	}
+	before := m
+	if err := m.Update(); err != nil || m != before {
+		t.Error("an empty update changed the posterior")
+	}
+}
+
+func TestNormalInvGamma(t *testing.T) {
+	xs := []float64{4.1, 5.3, 3.8, 6.0, 5.5, 4.7}
+	// Updating in one batch and one point at a time must agree.
+	batch := NormalInvGamma{Mu: 0, Lambda: 0.5, Alpha: 2, Beta: 3}
+	if err := batch.Update(xs...); err != nil {
+		t.Fatal(err)
+	}
+	before := batch
+	if err := batch.Update(4, math.NaN()); !errors.Is(err, randx.ErrInvalidParameter) || batch != before {
+		t.Errorf("Update with NaN: error = %v, posterior %+v, want ErrInvalidParameter and %+v", err, batch, before)
+	}
+	seq := NormalInvGamma{Mu: 0, Lambda: 0.5, Alpha: 2, Beta: 3}
+	for _, x := range xs {
+		seq.Update(x)
+	}
+	if math.Abs(batch.Mu-seq.Mu) > 1e-12 || math.Abs(batch.Beta-seq.Beta) > 1e-12 ||
+		batch.Lambda != seq.Lambda || batch.Alpha != seq.Alpha {
+		t.Errorf("batch %+v, sequential %+v", batch, seq)
+	}
+
+	// Under a vague prior the posterior of μ approaches the classical
+	// t interval: x̄ ± t_{n-1}·s/√n.
+	vague := NormalInvGamma{Lambda: 1e-9, Alpha: -0.5, Beta: 0}
+	vague.Update(xs...)
+	n := float64(len(xs))
+	mean, ss := 0.0, 0.0
+	for _, x := range xs {
+		mean += x / n
+	}
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	half := randx.StudentTDist{Nu: n - 1}.Quantile(0.975) * math.Sqrt(ss/(n-1)/n)
+	lo, hi := vague.CredibleInterval(0.95)
+	if math.Abs(lo-(mean-half)) > 1e-6 || math.Abs(hi-(mean+half)) > 1e-6 {
+		t.Errorf("vague interval [%v, %v], want %v ± %v", lo, hi, mean, half)
+	}
+
+	// Samples of μ follow the Student-t marginal.
+	post := batch.Posterior()
+	r := randx.NewRand(2)
+	below := 0
+	q := post.(randx.Quantiler).Quantile(0.3)
+	for range 20000 {
+		if batch.Sample(r) <= q {
+			below++
+		}
+	}
+	if got := float64(below) / 20000; math.Abs(got-0.3) > 5*math.Sqrt(0.3*0.7/20000) {
+		t.Errorf("P(μ ≤ q30) = %v, want 0.3", got)
+	}
+	if pred := batch.Predictive().(randx.AffineDist); !(pred.Scale > post.(randx.AffineDist).Scale) {
+		t.Errorf("predictive scale %v not above posterior %+v", pred.Scale, post)
+	}
+}
+
+func TestThompson(t *testing.T) {
+	// Arm 2 converts at 12%, the others at 5% and 8%.
+	rates := []float64{0.05, 0.08, 0.12}
+	b := NewThompson(len(rates), BetaBinomial{Alpha: 1, Beta: 1})
+	r := randx.NewRand(3)
+	pulls := make([]int, len(rates))
+	for range 5000 {
+		arm, err := b.Select(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pulls[arm]++
+		win := 0
+		if r.Float64() < rates[arm] {
+			win = 1
+		}
+		b.Arms[arm].Update(win, 1)
+	}
+	if pulls[2] < 2500 {
+		t.Errorf("best arm pulled %d of 5000 times (%v)", pulls[2], pulls)
+	}
+	best := b.ProbBest(r, 4000)
+	if sum := best[0] + best[1] + best[2]; math.Abs(sum-1) > 1e-12 || best[2] < 0.8 {
+		t.Errorf("ProbBest = %v", best)
+	}
+	if _, err := (&Thompson[GammaPoisson]{}).Select(r); err == nil {
+		t.Error("expected an error with no arms")
+	}
+
+	// A pointer prior is copied per arm, not shared.
+	prior := &BetaBinomial{Alpha: 1, Beta: 1}
+	shared := NewThompson(3, prior)
+	if err := shared.Arms[0].Update(5, 5); err != nil {
+		t.Fatal(err)
+	}
+	if *shared.Arms[1] != (BetaBinomial{Alpha: 1, Beta: 1}) || *prior != (BetaBinomial{Alpha: 1, Beta: 1}) {
+		t.Errorf("updating arm 0 changed arm 1 to %+v and the prior to %+v", *shared.Arms[1], *prior)
+	}
+}
@@ -0,0 +1,118 @@
+package bayes
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/miguelm-revel/revelTools/randx"
+	"github.com/miguelm-revel/revelTools/randx/special"
+)
+
+// BetaBinomialDist is the number of successes in N binomial trials whose
+// success probability is itself drawn from Beta(Alpha, Beta). It is the
+// posterior predictive of BetaBinomial.
+type BetaBinomialDist struct {
+	N           int
+	Alpha, Beta float64
+}
+
+func init() {
+	if err := randx.Register[BetaBinomialDist]("betabinomial", "n", "alpha", "beta"); err != nil {
+		panic(err)
+	}
+}
+
+func (b BetaBinomialDist) MarshalJSON() ([]byte, error)     { return randx.MarshalDist(b) }
+func (b *BetaBinomialDist) UnmarshalJSON(data []byte) error { return randx.UnmarshalDistInto(data, b) }
+
+func (b BetaBinomialDist) Validate() error {
+	switch {
+	case b.N < 0:
+		return invalid("BetaBinomialDist", "N", float64(b.N), ">= 0")
+	case !(b.Alpha > 0) || math.IsInf(b.Alpha, 1):
+		return invalid("BetaBinomialDist", "Alpha", b.Alpha, "> 0")
+	case !(b.Beta > 0) || math.IsInf(b.Beta, 1):
+		return invalid("BetaBinomialDist", "Beta", b.Beta, "> 0")
+	}
+	return nil
+}
+
+func (b BetaBinomialDist) Rand() float64 {
+	return b.RandWith(nil)
+}
+
+func (b BetaBinomialDist) RandWith(r *rand.Rand) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	p := randx.BetaDist{Alpha: b.Alpha, Beta: b.Beta}.RandWith(r)
+	return randx.BinomDist{N: b.N, P: p}.RandWith(r)
+}
+
+func (b BetaBinomialDist) PDF(x float64) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	k := int(math.Round(x))
+	if float64(k) != x || k < 0 || k > b.N {
+		return 0
+	}
+	return math.Exp(b.logPMF(k))
+}
+
+// logPMF returns ln[C(N, k)·B(k+α, N-k+β)/B(α, β)].
+func (b BetaBinomialDist) logPMF(k int) float64 {
+	n, kf := float64(b.N), float64(k)
+	return special.LogGamma(n+1) - special.LogGamma(kf+1) - special.LogGamma(n-kf+1) +
+		special.LogBeta(kf+b.Alpha, n-kf+b.Beta) - special.LogBeta(b.Alpha, b.Beta)
+}
+
+// CDF sums the PMF from 0, so it costs O(N) per call.
+func (b BetaBinomialDist) CDF(x float64) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 0 {
+		return 0
+	}
+	if k >= float64(b.N) {
+		return 1
+	}
+	sum := 0.0
+	for i := 0; i <= int(k); i++ {
+		sum += math.Exp(b.logPMF(i))
+	}
+	return math.Min(sum, 1)
+}
+
+// Quantile accumulates the PMF from 0 until it reaches p, so it costs O(N)
+// per call; for repeated queries on a large N, tabulate the CDF once.
+func (b BetaBinomialDist) Quantile(p float64) float64 {
+	if b.Validate() != nil || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for k := 0; k < b.N; k++ {
+		sum += math.Exp(b.logPMF(k))
+		if sum >= p {
+			return float64(k)
+		}
+	}
+	return float64(b.N)
+}
+
+func (b BetaBinomialDist) Mean() float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	return float64(b.N) * b.Alpha / (b.Alpha + b.Beta)
+}
+
+func (b BetaBinomialDist) Variance() float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	n, s := float64(b.N), b.Alpha+b.Beta
+	return n * b.Alpha * b.Beta * (s + n) / (s * s * (s + 1))
+}
@@ -0,0 +1,239 @@
+// Package bayes implements conjugate Bayesian models whose likelihoods are
+// randx distributions, and Thompson sampling on top of them. Each model is a
+// plain struct holding the current hyperparameters: set it to the prior,
+// fold data in with Update, and read the posterior back as a randx.Dist.
+// Invalid hyperparameters propagate as NaN through the returned
+// distributions, like the rest of randx, and Update rejects observations the
+// likelihood cannot produce with a *randx.ParamError, leaving the model as it
+// was.
+package bayes
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"github.com/miguelm-revel/revelTools/randx"
+)
+
+// BetaBinomial is a Beta(Alpha, Beta) prior on the success probability p of
+// binomial or Bernoulli trials. Beta(1, 1) is the uniform prior.
+type BetaBinomial struct {
+	Alpha, Beta float64
+}
+
+// Update records successes out of trials, which requires
+// 0 <= successes <= trials.
+func (m *BetaBinomial) Update(successes, trials int) error {
+	if trials < 0 {
+		return invalid("BetaBinomial", "trials", float64(trials), ">= 0")
+	}
+	if successes < 0 || successes > trials {
+		return invalid("BetaBinomial", "successes", float64(successes), "in [0, trials]")
+	}
+	m.Alpha += float64(successes)
+	m.Beta += float64(trials - successes)
+	return nil
+}
+
+// Posterior returns the distribution of p.
+func (m BetaBinomial) Posterior() randx.Dist {
+	return randx.BetaDist{Alpha: m.Alpha, Beta: m.Beta}
+}
+
+// Predictive returns the distribution of the number of successes in n
+// future trials.
+func (m BetaBinomial) Predictive(n int) randx.Dist {
+	return BetaBinomialDist{N: n, Alpha: m.Alpha, Beta: m.Beta}
+}
+
+// CredibleInterval returns the equal-tailed interval holding level of the
+// posterior mass of p.
+func (m BetaBinomial) CredibleInterval(level float64) (lo, hi float64) {
+	return credible(randx.BetaDist{Alpha: m.Alpha, Beta: m.Beta}, level)
+}
+
+// Sample draws p from the posterior.
+func (m BetaBinomial) Sample(r *rand.Rand) float64 {
+	return randx.BetaDist{Alpha: m.Alpha, Beta: m.Beta}.RandWith(r)
+}
+
+// GammaPoisson is a Gamma prior with the given Shape and Rate on the mean λ
+// of Poisson counts.
+type GammaPoisson struct {
+	Shape, Rate float64
+}
+
+// Update records counts, each observed over one unit of exposure. Every count
+// must be a non-negative integer.
+func (m *GammaPoisson) Update(counts ...float64) error {
+	for i, k := range counts {
+		if !(k >= 0) || k != math.Floor(k) || math.IsInf(k, 1) {
+			return invalid("GammaPoisson", fmt.Sprintf("counts[%d]", i), k, "a non-negative integer")
+		}
+	}
+	for _, k := range counts {
+		m.Shape += k
+	}
+	m.Rate += float64(len(counts))
+	return nil
+}
+
+// Posterior returns the distribution of λ.
+func (m GammaPoisson) Posterior() randx.Dist {
+	return m.gamma()
+}
+
+func (m GammaPoisson) gamma() randx.GammaDist {
+	return randx.GammaDist{Shape: m.Shape, Scale: 1 / m.Rate}
+}
+
+// Predictive returns the distribution of the next count, a negative binomial
+// with Shape successes and success probability Rate/(Rate+1).
+func (m GammaPoisson) Predictive() randx.Dist {
+	return randx.NegBinomialDist{R: m.Shape, P: m.Rate / (m.Rate + 1)}
+}
+
+func (m GammaPoisson) CredibleInterval(level float64) (lo, hi float64) {
+	return credible(m.gamma(), level)
+}
+
+// Sample draws λ from the posterior.
+func (m GammaPoisson) Sample(r *rand.Rand) float64 {
+	return m.gamma().RandWith(r)
+}
+
+// NormalNormal is a Normal(Mu, Var) prior on the mean μ of normal
+// observations with known variance NoiseVar.
+type NormalNormal struct {
+	Mu, Var  float64
+	NoiseVar float64
+}
+
+// Update records observations xs. Precisions add, and the posterior mean is
+// the precision-weighted average of the prior mean and the data. It returns
+// an error if any observation is not finite.
+func (m *NormalNormal) Update(xs ...float64) error {
+	if err := checkFinite("NormalNormal", xs); err != nil || len(xs) == 0 {
+		return err
+	}
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	post := 1 / (1/m.Var + float64(len(xs))/m.NoiseVar)
+	m.Mu = post * (m.Mu/m.Var + sum/m.NoiseVar)
+	m.Var = post
+	return nil
+}
+
+// Posterior returns the distribution of μ.
+func (m NormalNormal) Posterior() randx.Dist {
+	return m.normal()
+}
+
+func (m NormalNormal) normal() randx.NormalDist {
+	return randx.NormalDist{Mu: m.Mu, Sigma: math.Sqrt(m.Var)}
+}
+
+// Predictive returns the distribution of the next observation.
+func (m NormalNormal) Predictive() randx.Dist {
+	return randx.NormalDist{Mu: m.Mu, Sigma: math.Sqrt(m.Var + m.NoiseVar)}
+}
+
+func (m NormalNormal) CredibleInterval(level float64) (lo, hi float64) {
+	return credible(m.normal(), level)
+}
+
+// Sample draws μ from the posterior.
+func (m NormalNormal) Sample(r *rand.Rand) float64 {
+	return m.normal().RandWith(r)
+}
+
+// NormalInvGamma is the Normal–Inverse-Gamma prior on the mean μ and
+// variance σ² of normal observations when both are unknown:
+// σ² ~ InvGamma(Alpha, Beta) and μ | σ² ~ Normal(Mu, σ²/Lambda).
+type NormalInvGamma struct {
+	Mu, Lambda  float64
+	Alpha, Beta float64
+}
+
+// Update records observations xs. It returns an error if any observation is
+// not finite.
+func (m *NormalInvGamma) Update(xs ...float64) error {
+	if err := checkFinite("NormalInvGamma", xs); err != nil || len(xs) == 0 {
+		return err
+	}
+	n := float64(len(xs))
+	mean := 0.0
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= n
+	ss := 0.0
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	lambda := m.Lambda + n
+	m.Beta += 0.5*ss + 0.5*m.Lambda*n*(mean-m.Mu)*(mean-m.Mu)/lambda
+	m.Mu = (m.Lambda*m.Mu + n*mean) / lambda
+	m.Lambda = lambda
+	m.Alpha += 0.5 * n
+	return nil
+}
+
+// Posterior returns the marginal distribution of μ, a Student-t with 2·Alpha
+// degrees of freedom centred on Mu with scale √(Beta/(Alpha·Lambda)).
+func (m NormalInvGamma) Posterior() randx.Dist {
+	return m.studentT(m.Beta / (m.Alpha * m.Lambda))
+}
+
+// Predictive returns the distribution of the next observation, a Student-t
+// with 2·Alpha degrees of freedom and scale √(Beta·(Lambda+1)/(Alpha·Lambda)).
+func (m NormalInvGamma) Predictive() randx.Dist {
+	return m.studentT(m.Beta * (m.Lambda + 1) / (m.Alpha * m.Lambda))
+}
+
+func (m NormalInvGamma) studentT(scale2 float64) randx.AffineDist {
+	return randx.AffineDist{D: randx.StudentTDist{Nu: 2 * m.Alpha}, Loc: m.Mu, Scale: math.Sqrt(scale2)}
+}
+
+// CredibleInterval returns the equal-tailed interval for μ.
+func (m NormalInvGamma) CredibleInterval(level float64) (lo, hi float64) {
+	return credible(m.studentT(m.Beta/(m.Alpha*m.Lambda)), level)
+}
+
+// SampleParams draws (μ, σ²) jointly from the posterior.
+func (m NormalInvGamma) SampleParams(r *rand.Rand) (mu, variance float64) {
+	variance = m.Beta / randx.GammaDist{Shape: m.Alpha, Scale: 1}.RandWith(r)
+	mu = randx.NormalDist{Mu: m.Mu, Sigma: math.Sqrt(variance / m.Lambda)}.RandWith(r)
+	return mu, variance
+}
+
+// Sample draws μ from the posterior.
+func (m NormalInvGamma) Sample(r *rand.Rand) float64 {
+	mu, _ := m.SampleParams(r)
+	return mu
+}
+
+func invalid(dist, field string, v float64, want string) error {
+	return &randx.ParamError{Dist: dist, Field: field, Value: v, Want: want}
+}
+
+// checkFinite rejects the first non-finite observation in xs.
+func checkFinite(dist string, xs []float64) error {
+	for i, x := range xs {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return invalid(dist, fmt.Sprintf("xs[%d]", i), x, "finite")
+		}
+	}
+	return nil
+}
+
+// credible returns the (1-level)/2 and (1+level)/2 quantiles of d.
+func credible(d randx.Quantiler, level float64) (lo, hi float64) {
+	if !(level > 0 && level < 1) {
+		return math.NaN(), math.NaN()
+	}
+	return d.Quantile((1 - level) / 2), d.Quantile((1 + level) / 2)
+}
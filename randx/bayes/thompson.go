@@ -0,0 +1,79 @@
+package bayes
+
+import (
+	"errors"
+	"math/rand/v2"
+	"reflect"
+)
+
+// Arm is a posterior over the expected reward of a bandit arm. Every model
+// in this package is an Arm.
+type Arm interface {
+	// Sample draws an expected reward from the posterior.
+	Sample(r *rand.Rand) float64
+}
+
+// Thompson is a multi-armed bandit that plays each arm with the posterior
+// probability that it is the best one (Thompson sampling). Record rewards by
+// updating the arms directly, e.g. t.Arms[i].Update(1, 1). Thompson is not
+// safe for concurrent use.
+type Thompson[A Arm] struct {
+	Arms []A
+}
+
+// NewThompson returns a bandit with n arms, each starting at prior. When
+// prior is a pointer, such as &BetaBinomial{1, 1}, every arm gets its own
+// shallow copy of the pointed-to model, so updating one arm leaves the others
+// alone.
+func NewThompson[A Arm](n int, prior A) *Thompson[A] {
+	arms := make([]A, n)
+	for i := range arms {
+		arms[i] = cloneArm(prior)
+	}
+	return &Thompson[A]{Arms: arms}
+}
+
+// cloneArm returns a copy of the model a points to, or a itself when it is
+// not a non-nil pointer.
+func cloneArm[A Arm](a A) A {
+	v := reflect.ValueOf(a)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return a
+	}
+	c := reflect.New(v.Elem().Type())
+	c.Elem().Set(v.Elem())
+	return c.Interface().(A)
+}
+
+// Select draws one sample from every arm's posterior and returns the arm
+// with the largest. It returns an error if there are no arms.
+func (t *Thompson[A]) Select(r *rand.Rand) (int, error) {
+	if len(t.Arms) == 0 {
+		return 0, errors.New("thompson: no arms")
+	}
+	best, bestV := 0, t.Arms[0].Sample(r)
+	for i := 1; i < len(t.Arms); i++ {
+		if v := t.Arms[i].Sample(r); v > bestV {
+			best, bestV = i, v
+		}
+	}
+	return best, nil
+}
+
+// ProbBest estimates, from draws joint posterior samples, the probability
+// that each arm has the largest expected reward, which is the usual stopping
+// criterion of an A/B test.
+func (t *Thompson[A]) ProbBest(r *rand.Rand, draws int) []float64 {
+	wins := make([]float64, len(t.Arms))
+	if len(t.Arms) == 0 || draws <= 0 {
+		return wins
+	}
+	for range draws {
+		i, _ := t.Select(r)
+		wins[i]++
+	}
+	for i := range wins {
+		wins[i] /= float64(draws)
+	}
+	return wins
+}
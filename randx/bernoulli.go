@@ -0,0 +1,158 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+type BernoulliDist struct {
+	P float64
+}
+
+// NewBernoulli validates and returns BernoulliDist{P: p}.
+func NewBernoulli(p float64) (BernoulliDist, error) {
+	d := BernoulliDist{P: p}
+	return d, d.Validate()
+}
+
+func (b BernoulliDist) Validate() error {
+	if !probability(b.P) {
+		return paramError("BernoulliDist", "P", b.P, "in [0, 1]")
+	}
+	return nil
+}
+
+func (b BernoulliDist) Rand() float64 {
+	return b.RandWith(nil)
+}
+
+func (b BernoulliDist) RandWith(r *rand.Rand) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	if uniform(r) < b.P {
+		return 1
+	}
+	return 0
+}
+
+func (b BernoulliDist) PDF(x float64) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	switch x {
+	case 0:
+		return 1 - b.P
+	case 1:
+		return b.P
+	default:
+		return 0
+	}
+}
+
+func (b BernoulliDist) CDF(x float64) float64 {
+	if b.Validate() != nil || math.IsNaN(x) {
+		return math.NaN()
+	}
+	switch {
+	case x < 0:
+		return 0
+	case x < 1:
+		return 1 - b.P
+	default:
+		return 1
+	}
+}
+
+func (b BernoulliDist) Quantile(q float64) float64 {
+	if b.Validate() != nil || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	if q <= 1-b.P {
+		return 0
+	}
+	return 1
+}
+
+func (b BernoulliDist) Mean() float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	return b.P
+}
+
+func (b BernoulliDist) Variance() float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	return b.P * (1 - b.P)
+}
+
+func (b BernoulliDist) StdDev() float64 {
+	return math.Sqrt(b.Variance())
+}
+
+// Skewness is NaN when P is 0 or 1, where the distribution is a point mass.
+func (b BernoulliDist) Skewness() float64 {
+	if b.Validate() != nil || b.Variance() == 0 {
+		return math.NaN()
+	}
+	return (1 - 2*b.P) / math.Sqrt(b.Variance())
+}
+
+func (b BernoulliDist) ExKurtosis() float64 {
+	if b.Validate() != nil || b.Variance() == 0 {
+		return math.NaN()
+	}
+	v := b.Variance()
+	return (1 - 6*v) / v
+}
+
+// Mode returns 1 when P > 0.5 and 0 otherwise.
+func (b BernoulliDist) Mode() float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	if b.P > 0.5 {
+		return 1
+	}
+	return 0
+}
+
+func (b BernoulliDist) Median() float64 {
+	return b.Quantile(0.5)
+}
+
+func (b BernoulliDist) Entropy() float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	pmf := func(k int) float64 { return b.PDF(float64(k)) }
+	return discreteEntropy(pmf, 0, 1)
+}
+
+func (b BernoulliDist) LogPDF(x float64) float64 {
+	return math.Log(b.PDF(x))
+}
+
+func (b BernoulliDist) LogCDF(x float64) float64 {
+	return math.Log(b.CDF(x))
+}
+
+func (b BernoulliDist) Survival(x float64) float64 {
+	if b.Validate() != nil || math.IsNaN(x) {
+		return math.NaN()
+	}
+	switch {
+	case x < 0:
+		return 1
+	case x < 1:
+		return b.P
+	default:
+		return 0
+	}
+}
+
+func (b BernoulliDist) LogSurvival(x float64) float64 {
+	return math.Log(b.Survival(x))
+}
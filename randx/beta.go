@@ -0,0 +1,223 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/miguelm-revel/revelTools/randx/special"
+)
+
+type BetaDist struct {
+	Alpha, Beta float64
+}
+
+// NewBeta validates and returns BetaDist{Alpha: alpha, Beta: beta}.
+func NewBeta(alpha, beta float64) (BetaDist, error) {
+	d := BetaDist{Alpha: alpha, Beta: beta}
+	return d, d.Validate()
+}
+
+func (b BetaDist) Validate() error {
+	if !positive(b.Alpha) {
+		return paramError("BetaDist", "Alpha", b.Alpha, "> 0")
+	}
+	if !positive(b.Beta) {
+		return paramError("BetaDist", "Beta", b.Beta, "> 0")
+	}
+	return nil
+}
+
+func (b BetaDist) Rand() float64 {
+	return b.RandWith(nil)
+}
+
+func (b BetaDist) RandWith(r *rand.Rand) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	return logisticRatio(logGammaRand(r, b.Alpha), logGammaRand(r, b.Beta))
+}
+
+func (b BetaDist) PDF(x float64) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Exp(b.LogPDF(x))
+}
+
+func (b BetaDist) CDF(x float64) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	return special.RegIncBeta(b.Alpha, b.Beta, x)
+}
+
+func (b BetaDist) Quantile(p float64) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	return special.InvRegIncBeta(b.Alpha, b.Beta, p)
+}
+
+func (b BetaDist) Sample(n int) []float64 {
+	dst := make([]float64, n)
+	b.Fill(dst)
+	return dst
+}
+
+func (b BetaDist) Fill(dst []float64) {
+	b.FillWith(nil, dst)
+}
+
+// FillWith computes the Marsaglia–Tsang constants of both gamma variates once
+// for the whole batch. The variates are drawn in log space so that tiny shapes,
+// where both draws underflow to zero, still give a value in [0, 1].
+func (b BetaDist) FillWith(r *rand.Rand, dst []float64) {
+	if b.Validate() != nil {
+		fillNaN(dst)
+		return
+	}
+	gx := newGammaSampler(b.Alpha)
+	gy := newGammaSampler(b.Beta)
+	for i := range dst {
+		dst[i] = logisticRatio(gx.logSample(r), gy.logSample(r))
+	}
+}
+
+func (b BetaDist) Mean() float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	return b.Alpha / (b.Alpha + b.Beta)
+}
+
+func (b BetaDist) Variance() float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	s := b.Alpha + b.Beta
+	return b.Alpha * b.Beta / (s * s * (s + 1))
+}
+
+func (b BetaDist) StdDev() float64 {
+	return math.Sqrt(b.Variance())
+}
+
+func (b BetaDist) Skewness() float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	s := b.Alpha + b.Beta
+	return 2 * (b.Beta - b.Alpha) * math.Sqrt(s+1) / ((s + 2) * math.Sqrt(b.Alpha*b.Beta))
+}
+
+func (b BetaDist) ExKurtosis() float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	s := b.Alpha + b.Beta
+	d := b.Alpha - b.Beta
+	return 6 * (d*d*(s+1) - b.Alpha*b.Beta*(s+2)) / (b.Alpha * b.Beta * (s + 2) * (s + 3))
+}
+
+// Mode returns the density's maximum. For Alpha, Beta < 1 the density is
+// U-shaped with maxima at both 0 and 1, and Mode returns NaN; for
+// Alpha = Beta = 1 every point is a mode and Mode returns 0.5.
+func (b BetaDist) Mode() float64 {
+	switch {
+	case b.Validate() != nil:
+		return math.NaN()
+	case b.Alpha > 1 && b.Beta > 1:
+		return (b.Alpha - 1) / (b.Alpha + b.Beta - 2)
+	case b.Alpha == 1 && b.Beta == 1:
+		return 0.5
+	case b.Alpha <= 1 && b.Beta >= 1:
+		return 0
+	case b.Alpha >= 1 && b.Beta <= 1:
+		return 1
+	default:
+		return math.NaN()
+	}
+}
+
+func (b BetaDist) Median() float64 {
+	return b.Quantile(0.5)
+}
+
+func (b BetaDist) Entropy() float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	return special.LogBeta(b.Alpha, b.Beta) - (b.Alpha-1)*special.Digamma(b.Alpha) - (b.Beta-1)*special.Digamma(b.Beta) +
+		(b.Alpha+b.Beta-2)*special.Digamma(b.Alpha+b.Beta)
+}
+
+func (b BetaDist) LogPDF(x float64) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	if x < 0 || x > 1 {
+		return math.Inf(-1)
+	}
+	if (x == 0 && b.Alpha < 1) || (x == 1 && b.Beta < 1) {
+		return math.Inf(1)
+	}
+	if (x == 0 && b.Alpha == 1) || (x == 1 && b.Beta == 1) {
+		return -special.LogBeta(b.Alpha, b.Beta)
+	}
+	if x == 0 || x == 1 {
+		return math.Inf(-1)
+	}
+	return (b.Alpha-1)*math.Log(x) + (b.Beta-1)*math.Log1p(-x) - special.LogBeta(b.Alpha, b.Beta)
+}
+
+func (b BetaDist) LogCDF(x float64) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return math.Inf(-1)
+	}
+	if x >= 1 {
+		return 0
+	}
+	return special.LogRegIncBeta(b.Alpha, b.Beta, x)
+}
+
+// Survival returns I_{1-x}(β, α), which equals 1 - I_x(α, β).
+func (b BetaDist) Survival(x float64) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 1
+	}
+	if x >= 1 {
+		return 0
+	}
+	return special.RegIncBeta(b.Beta, b.Alpha, 1-x)
+}
+
+func (b BetaDist) LogSurvival(x float64) float64 {
+	if b.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return math.Inf(-1)
+	}
+	return special.LogRegIncBeta(b.Beta, b.Alpha, 1-x)
+}
+
+// logisticRatio returns x/(x+y) given lx = ln x and ly = ln y.
+func logisticRatio(lx, ly float64) float64 {
+	return 1 / (1 + math.Exp(ly-lx))
+}
@@ -0,0 +1,72 @@
+package randx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBinom_CDFMatchesPMFSum(t *testing.T) {
+	for _, b := range []BinomDist{{N: 30, P: 0.2}, {N: 200, P: 0.93}, {N: 5, P: 0.5}} {
+		sum := 0.0
+		for k := 0; k < b.N; k++ {
+			sum += b.PDF(float64(k))
+			if got := b.CDF(float64(k)); math.Abs(got-sum) > 1e-12 {
+				t.Fatalf("%+v: CDF(%d) = %v, want %v", b, k, got, sum)
+			}
+		}
+	}
+}
+
+// TestBinom_SamplerFitsPMF runs a chi-square goodness-of-fit check over both
+// the inversion (n·p < 30) and BTPE branches, on either side of p = 0.5.
+func TestBinom_SamplerFitsPMF(t *testing.T) {
+	const draws = 200000
+	r := NewRand(11)
+	for _, b := range []BinomDist{
+		{N: 40, P: 0.3},
+		{N: 100, P: 0.29},
+		{N: 100, P: 0.75},
+		{N: 1000, P: 0.5},
+		{N: 5_000_000, P: 0.01},
+	} {
+		counts := map[int]int{}
+		for range draws {
+			counts[int(b.RandWith(r))]++
+		}
+
+		// Bins with an expected count below 5 are pooled into the tails.
+		lo := int(b.Quantile(1e-4))
+		hi := int(b.Quantile(1 - 1e-4))
+		stat, bins := 0.0, 0
+		for k := lo; k <= hi; k++ {
+			var obs int
+			var exp float64
+			switch k {
+			case lo:
+				for j, c := range counts {
+					if j <= lo {
+						obs += c
+					}
+				}
+				exp = draws * b.CDF(float64(lo))
+			case hi:
+				for j, c := range counts {
+					if j >= hi {
+						obs += c
+					}
+				}
+				exp = draws * (1 - b.CDF(float64(hi-1)))
+			default:
+				obs = counts[k]
+				exp = draws * b.PDF(float64(k))
+			}
+			d := float64(obs) - exp
+			stat += d * d / exp
+			bins++
+		}
+		// Upper 1e-6 quantile keeps this deterministic test from flaking.
+		if crit := (Chi2Dist{K: float64(bins - 1)}).Quantile(1 - 1e-6); stat > crit {
+			t.Errorf("%+v: chi-square %v over %d bins exceeds %v", b, stat, bins, crit)
+		}
+	}
+}
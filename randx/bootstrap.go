@@ -0,0 +1,243 @@
+package randx
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/miguelm-revel/revelTools/randx/stats"
+)
+
+// resampleChunk is the number of resamples per parallel job.
+const resampleChunk = 64
+
+// Resampler configures bootstrap and permutation procedures. Resamples run in
+// parallel in fixed-size chunks, each with its own stream derived from Seed,
+// so results are reproducible regardless of Workers. Statistic functions are
+// called concurrently and must not retain or modify their argument.
+type Resampler struct {
+	// N is the number of resamples; 0 means 2000.
+	N int
+	// Workers is the number of goroutines; 0 means runtime.GOMAXPROCS(0).
+	Workers int
+	// Seed selects the random streams.
+	Seed uint64
+	// Level is the confidence level of bootstrap intervals; 0 means 0.95.
+	Level float64
+}
+
+func (rs Resampler) params() (n int, level float64, err error) {
+	n, level = rs.N, rs.Level
+	if n == 0 {
+		n = 2000
+	}
+	if level == 0 {
+		level = 0.95
+	}
+	if n < 1 {
+		return 0, 0, errors.New("randx: resample: number of resamples must be positive")
+	}
+	if !(level > 0 && level < 1) {
+		return 0, 0, errors.New("randx: resample: confidence level must be in (0, 1)")
+	}
+	return n, level, nil
+}
+
+// replicates evaluates stat on n bootstrap resamples of xs, drawn with
+// replacement, and returns the values sorted.
+func (rs Resampler) replicates(xs []float64, stat func([]float64) float64, n int) []float64 {
+	out := make([]float64, n)
+	forChunks(rs.Workers, n, resampleChunk, func(chunk, lo, hi int) {
+		r := chunkRand(rs.Seed, uint64(chunk))
+		buf := make([]float64, len(xs))
+		for i := lo; i < hi; i++ {
+			for j := range buf {
+				buf[j] = xs[r.IntN(len(xs))]
+			}
+			out[i] = stat(buf)
+		}
+	})
+	slices.Sort(out)
+	return out
+}
+
+// Percentile returns the bootstrap percentile interval for stat(xs): the
+// (1-Level)/2 and (1+Level)/2 quantiles of stat over resamples of xs. StdErr
+// is the standard deviation of the replicates and N the number of resamples.
+func (rs Resampler) Percentile(xs []float64, stat func([]float64) float64) (Estimate, error) {
+	n, level, err := rs.params()
+	if err != nil {
+		return Estimate{}, err
+	}
+	if len(xs) == 0 {
+		return Estimate{}, errors.New("randx: resample: empty sample")
+	}
+	reps := rs.replicates(xs, stat, n)
+	return Estimate{
+		Value:  stat(xs),
+		StdErr: sampleStdDev(reps),
+		Lo:     stats.QuantileSorted(reps, (1-level)/2),
+		Hi:     stats.QuantileSorted(reps, (1+level)/2),
+		N:      n,
+	}, nil
+}
+
+// BCa returns Efron's bias-corrected and accelerated bootstrap interval for
+// stat(xs). The percentiles of the replicates are shifted by the bias
+// correction z₀, the normal quantile of the fraction of replicates below
+// stat(xs), and by the acceleration a, estimated from the jackknife. It
+// returns an error if the replicates all lie on one side of stat(xs).
+func (rs Resampler) BCa(xs []float64, stat func([]float64) float64) (Estimate, error) {
+	n, level, err := rs.params()
+	if err != nil {
+		return Estimate{}, err
+	}
+	if len(xs) < 2 {
+		return Estimate{}, errors.New("randx: resample: BCa needs at least two observations")
+	}
+	theta := stat(xs)
+	reps := rs.replicates(xs, stat, n)
+
+	below := 0.0
+	for _, v := range reps {
+		if v < theta {
+			below++
+		} else if v == theta {
+			below += 0.5
+		}
+	}
+	if below == 0 || below == float64(n) {
+		return Estimate{}, errors.New("randx: resample: bootstrap distribution is degenerate")
+	}
+	std := NormalDist{Mu: 0, Sigma: 1}
+	z0 := std.Quantile(below / float64(n))
+
+	jack := jackknifeValues(xs, stat)
+	mean := 0.0
+	for _, v := range jack {
+		mean += v
+	}
+	mean /= float64(len(jack))
+	var num, den float64
+	for _, v := range jack {
+		d := mean - v
+		num += d * d * d
+		den += d * d
+	}
+	a := 0.0
+	if den > 0 {
+		a = num / (6 * math.Pow(den, 1.5))
+	}
+
+	adjust := func(p float64) float64 {
+		z := std.Quantile(p)
+		return std.CDF(z0 + (z0+z)/(1-a*(z0+z)))
+	}
+	return Estimate{
+		Value:  theta,
+		StdErr: sampleStdDev(reps),
+		Lo:     stats.QuantileSorted(reps, adjust((1-level)/2)),
+		Hi:     stats.QuantileSorted(reps, adjust((1+level)/2)),
+		N:      n,
+	}, nil
+}
+
+// jackknifeValues returns stat evaluated on xs with each observation left
+// out in turn.
+func jackknifeValues(xs []float64, stat func([]float64) float64) []float64 {
+	out := make([]float64, len(xs))
+	buf := make([]float64, len(xs)-1)
+	for i := range xs {
+		copy(buf, xs[:i])
+		copy(buf[i:], xs[i+1:])
+		out[i] = stat(buf)
+	}
+	return out
+}
+
+// Jackknife returns the jackknife bias-corrected estimate of stat(xs), its
+// jackknife standard error and a Student-t interval with n-1 degrees of
+// freedom at the given confidence level.
+func Jackknife(xs []float64, stat func([]float64) float64, level float64) (Estimate, error) {
+	n := len(xs)
+	if n < 2 {
+		return Estimate{}, errors.New("randx: resample: jackknife needs at least two observations")
+	}
+	if !(level > 0 && level < 1) {
+		return Estimate{}, errors.New("randx: resample: confidence level must be in (0, 1)")
+	}
+	vals := jackknifeValues(xs, stat)
+	mean := 0.0
+	for _, v := range vals {
+		mean += v
+	}
+	mean /= float64(n)
+	ss := 0.0
+	for _, v := range vals {
+		ss += (v - mean) * (v - mean)
+	}
+	fn := float64(n)
+	value := fn*stat(xs) - (fn-1)*mean
+	se := math.Sqrt((fn - 1) / fn * ss)
+	half := StudentTDist{Nu: fn - 1}.Quantile(0.5+level/2) * se
+	return Estimate{Value: value, StdErr: se, Lo: value - half, Hi: value + half, N: n}, nil
+}
+
+// PermutationResult is the outcome of a permutation test.
+type PermutationResult struct {
+	Statistic float64
+	PValue    float64
+}
+
+// MeanDiff returns mean(xs) - mean(ys), the default permutation statistic.
+func MeanDiff(xs, ys []float64) float64 {
+	var sx, sy float64
+	for _, x := range xs {
+		sx += x
+	}
+	for _, y := range ys {
+		sy += y
+	}
+	return sx/float64(len(xs)) - sy/float64(len(ys))
+}
+
+// Permutation runs a two-sided permutation test of whether xs and ys come
+// from the same distribution, using stat (MeanDiff when nil). Each resample
+// reassigns the pooled observations to groups of the original sizes at
+// random; the p-value is (1 + #{|T*| >= |T|}) / (1 + N), which is never 0.
+func (rs Resampler) Permutation(xs, ys []float64, stat func(xs, ys []float64) float64) (PermutationResult, error) {
+	n, _, err := rs.params()
+	if err != nil {
+		return PermutationResult{}, err
+	}
+	if len(xs) == 0 || len(ys) == 0 {
+		return PermutationResult{}, errors.New("randx: resample: empty group")
+	}
+	if stat == nil {
+		stat = MeanDiff
+	}
+	observed := stat(xs, ys)
+	// Rounding can make a relabelling of the observed split differ from it in
+	// the last bits; the slack keeps such ties counted.
+	threshold := math.Abs(observed) * (1 - 1e-12)
+	pooled := slices.Concat(xs, ys)
+	hits := make([]int, (n+resampleChunk-1)/resampleChunk)
+	forChunks(rs.Workers, n, resampleChunk, func(chunk, lo, hi int) {
+		r := chunkRand(rs.Seed, uint64(chunk))
+		buf := slices.Clone(pooled)
+		for range hi - lo {
+			Shuffle(r, buf)
+			if math.Abs(stat(buf[:len(xs)], buf[len(xs):])) >= threshold {
+				hits[chunk]++
+			}
+		}
+	})
+	total := 0
+	for _, h := range hits {
+		total += h
+	}
+	return PermutationResult{
+		Statistic: observed,
+		PValue:    float64(total+1) / float64(n+1),
+	}, nil
+}
@@ -0,0 +1,154 @@
+package randx
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/miguelm-revel/revelTools/randx/stats"
+)
+
+func mean(xs []float64) float64 {
+	s := 0.0
+	for _, x := range xs {
+		s += x
+	}
+	return s / float64(len(xs))
+}
+
+func biasedVariance(xs []float64) float64 {
+	m := mean(xs)
+	s := 0.0
+	for _, x := range xs {
+		s += (x - m) * (x - m)
+	}
+	return s / float64(len(xs))
+}
+
+func TestJackknife_ClosedForms(t *testing.T) {
+	xs := make([]float64, 30)
+	FillWith(GammaDist{Shape: 2, Scale: 1}, NewRand(1), xs)
+	n := float64(len(xs))
+	s := sampleStdDev(xs)
+
+	m, err := Jackknife(xs, mean, 0.95)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// For the mean the jackknife reproduces the mean and s/√n.
+	if math.Abs(m.Value-mean(xs)) > 1e-12 || math.Abs(m.StdErr-s/math.Sqrt(n)) > 1e-12 {
+		t.Errorf("jackknife of the mean = %+v, want %v ± %v", m, mean(xs), s/math.Sqrt(n))
+	}
+	half := StudentTDist{Nu: n - 1}.Quantile(0.975) * m.StdErr
+	if math.Abs(m.Hi-m.Value-half) > 1e-12 {
+		t.Errorf("half-width = %v, want %v", m.Hi-m.Value, half)
+	}
+	// Bias correction turns the 1/n variance into the unbiased 1/(n-1) one.
+	v, err := Jackknife(xs, biasedVariance, 0.9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(v.Value-s*s) > 1e-12 {
+		t.Errorf("jackknife variance = %v, want %v", v.Value, s*s)
+	}
+	if _, err := Jackknife(xs[:1], mean, 0.95); err == nil {
+		t.Error("expected an error for one observation")
+	}
+}
+
+func TestBootstrap_Intervals(t *testing.T) {
+	xs := make([]float64, 200)
+	FillWith(ExpDist{Lambda: 1}, NewRand(2), xs)
+	rs := Resampler{N: 4000, Seed: 7}
+	se := sampleStdDev(xs) / math.Sqrt(float64(len(xs)))
+
+	pct, err := rs.Percentile(xs, mean)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pct.Value != mean(xs) || pct.N != 4000 {
+		t.Errorf("percentile estimate = %+v", pct)
+	}
+	// The bootstrap SE of the mean is about s/√n (slightly less, since
+	// resampling uses the 1/n variance).
+	if math.Abs(pct.StdErr-se) > 0.1*se {
+		t.Errorf("bootstrap StdErr = %v, want ≈ %v", pct.StdErr, se)
+	}
+	if width := pct.Hi - pct.Lo; math.Abs(width-2*1.96*se) > 0.15*width {
+		t.Errorf("percentile width = %v, want ≈ %v", width, 2*1.96*se)
+	}
+
+	bca, err := rs.BCa(xs, mean)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !(bca.Lo < bca.Value && bca.Value < bca.Hi) {
+		t.Errorf("BCa interval [%v, %v] excludes %v", bca.Lo, bca.Hi, bca.Value)
+	}
+	// The mean of exponential data is right-skewed, so BCa shifts the
+	// interval up relative to the percentile one.
+	if !(bca.Hi-bca.Value > bca.Value-bca.Lo) || !(bca.Hi > pct.Hi) {
+		t.Errorf("BCa %+v is not right-skewed relative to percentile %+v", bca, pct)
+	}
+
+	if c, err := rs.BCa([]float64{3, 3, 3}, mean); err != nil || c.Lo != 3 || c.Hi != 3 {
+		t.Errorf("constant sample: %+v, %v", c, err)
+	}
+	if _, err := (Resampler{N: -1}).Percentile(xs, mean); err == nil {
+		t.Error("expected an error for negative N")
+	}
+}
+
+func TestBootstrap_ReproducibleAcrossWorkers(t *testing.T) {
+	xs := Sample(NormalDist{Mu: 0, Sigma: 1}, 50)
+	var first Estimate
+	for i, workers := range []int{1, 4} {
+		est, err := Resampler{N: 1000, Workers: workers, Seed: 3}.BCa(xs, mean)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			first = est
+		} else if est != first {
+			t.Errorf("%d workers gave %+v, 1 worker gave %+v", workers, est, first)
+		}
+	}
+}
+
+func TestPermutation(t *testing.T) {
+	r := NewRand(4)
+	draw := func(d Dist, n int) []float64 {
+		xs := make([]float64, n)
+		FillWith(d, r, xs)
+		return xs
+	}
+	rs := Resampler{N: 2000, Seed: 5}
+	same, err := rs.Permutation(draw(NormalDist{Mu: 0, Sigma: 1}, 40), draw(NormalDist{Mu: 0, Sigma: 1}, 30), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if same.PValue < 0.01 {
+		t.Errorf("equal groups rejected: %+v", same)
+	}
+	shifted, err := rs.Permutation(draw(NormalDist{Mu: 1, Sigma: 1}, 40), draw(NormalDist{Mu: 0, Sigma: 1}, 30), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shifted.PValue != 1.0/2001 || shifted.Statistic < 0.5 {
+		t.Errorf("shifted groups: %+v, want the minimum p-value 1/2001", shifted)
+	}
+	// A custom statistic: difference of medians.
+	median := func(xs []float64) float64 { return stats.QuantileSorted(slices.Sorted(slices.Values(xs)), 0.5) }
+	res, err := rs.Permutation([]float64{1, 2, 3}, []float64{1, 2, 3}, func(a, b []float64) float64 {
+		return median(a) - median(b)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.PValue != 1 {
+		t.Errorf("identical groups p = %v, want 1", res.PValue)
+	}
+	if _, err := rs.Permutation(nil, []float64{1}, nil); err == nil {
+		t.Error("expected an error for an empty group")
+	}
+}
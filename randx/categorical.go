@@ -0,0 +1,248 @@
+package randx
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// CategoricalDist draws the index i in [0, len(Weights)) with probability
+// proportional to Weights[i]. Weights need not be normalized but must be
+// finite, non-negative and not all zero.
+type CategoricalDist struct {
+	Weights []float64
+}
+
+// NewCategorical validates and returns CategoricalDist{Weights: weights}.
+// The weights are copied.
+func NewCategorical(weights []float64) (CategoricalDist, error) {
+	d := CategoricalDist{Weights: slices.Clone(weights)}
+	return d, d.Validate()
+}
+
+func (c CategoricalDist) Validate() error {
+	return validateWeights("CategoricalDist", "Weights", c.Weights)
+}
+
+// validateWeights checks that ws are finite, non-negative and not all zero.
+func validateWeights(dist, field string, ws []float64) error {
+	sum := 0.0
+	for i, w := range ws {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return paramError(dist, fmt.Sprintf("%s[%d]", field, i), w, "finite and >= 0")
+		}
+		sum += w
+	}
+	if !(sum > 0) {
+		return paramError(dist, field, sum, "a positive total")
+	}
+	return nil
+}
+
+// total returns the sum of the weights, or NaN if they are invalid.
+func (c CategoricalDist) total() float64 {
+	sum := 0.0
+	for _, w := range c.Weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return math.NaN()
+		}
+		sum += w
+	}
+	if sum == 0 {
+		return math.NaN()
+	}
+	return sum
+}
+
+func (c CategoricalDist) Rand() float64 {
+	return c.RandWith(nil)
+}
+
+func (c CategoricalDist) RandWith(r *rand.Rand) float64 {
+	total := c.total()
+	if math.IsNaN(total) {
+		return math.NaN()
+	}
+	u := uniform(r) * total
+	last := 0
+	for i, w := range c.Weights {
+		if w == 0 {
+			continue
+		}
+		if u < w {
+			return float64(i)
+		}
+		u -= w
+		last = i
+	}
+	// Rounding left u just above the final cumulative weight.
+	return float64(last)
+}
+
+func (c CategoricalDist) Sample(n int) []float64 {
+	dst := make([]float64, n)
+	c.Fill(dst)
+	return dst
+}
+
+func (c CategoricalDist) Fill(dst []float64) {
+	c.FillWith(nil, dst)
+}
+
+// FillWith builds an alias table once and draws every sample from it in O(1).
+func (c CategoricalDist) FillWith(r *rand.Rand, dst []float64) {
+	a, err := NewAlias(c.Weights)
+	if err != nil {
+		fillNaN(dst)
+		return
+	}
+	for i := range dst {
+		dst[i] = float64(a.RandWith(r))
+	}
+}
+
+func (c CategoricalDist) PDF(x float64) float64 {
+	total := c.total()
+	if math.IsNaN(total) {
+		return math.NaN()
+	}
+	k := int(math.Round(x))
+	if float64(k) != x || k < 0 || k >= len(c.Weights) {
+		return 0
+	}
+	return c.Weights[k] / total
+}
+
+func (c CategoricalDist) CDF(x float64) float64 {
+	total := c.total()
+	if math.IsNaN(total) || math.IsNaN(x) {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 0 {
+		return 0
+	}
+	if k >= float64(len(c.Weights)-1) {
+		return 1
+	}
+	sum := 0.0
+	for _, w := range c.Weights[:int(k)+1] {
+		sum += w
+	}
+	return sum / total
+}
+
+func (c CategoricalDist) Quantile(q float64) float64 {
+	total := c.total()
+	if math.IsNaN(total) || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	sum := 0.0
+	last := 0
+	for i, w := range c.Weights {
+		if w == 0 {
+			continue
+		}
+		sum += w
+		last = i
+		if sum/total >= q {
+			return float64(i)
+		}
+	}
+	return float64(last)
+}
+
+// rawMoment returns E[X^m] over the indices.
+func (c CategoricalDist) rawMoment(m float64) float64 {
+	total := c.total()
+	sum := 0.0
+	for i, w := range c.Weights {
+		sum += w * math.Pow(float64(i), m)
+	}
+	return sum / total
+}
+
+func (c CategoricalDist) Mean() float64 {
+	return c.rawMoment(1)
+}
+
+func (c CategoricalDist) Variance() float64 {
+	m1 := c.rawMoment(1)
+	return c.rawMoment(2) - m1*m1
+}
+
+func (c CategoricalDist) StdDev() float64 {
+	return math.Sqrt(c.Variance())
+}
+
+func (c CategoricalDist) Skewness() float64 {
+	m1, m2, m3 := c.rawMoment(1), c.rawMoment(2), c.rawMoment(3)
+	v := m2 - m1*m1
+	return (m3 - 3*m1*v - m1*m1*m1) / math.Pow(v, 1.5)
+}
+
+func (c CategoricalDist) ExKurtosis() float64 {
+	m1, m2, m3, m4 := c.rawMoment(1), c.rawMoment(2), c.rawMoment(3), c.rawMoment(4)
+	v := m2 - m1*m1
+	c4 := m4 - 4*m1*m3 + 6*m1*m1*m2 - 3*m1*m1*m1*m1
+	return c4/(v*v) - 3
+}
+
+// Mode returns the lowest index carrying the largest weight.
+func (c CategoricalDist) Mode() float64 {
+	if math.IsNaN(c.total()) {
+		return math.NaN()
+	}
+	best := 0
+	for i, w := range c.Weights {
+		if w > c.Weights[best] {
+			best = i
+		}
+	}
+	return float64(best)
+}
+
+func (c CategoricalDist) Median() float64 {
+	return c.Quantile(0.5)
+}
+
+func (c CategoricalDist) Entropy() float64 {
+	if math.IsNaN(c.total()) {
+		return math.NaN()
+	}
+	pmf := func(k int) float64 { return c.PDF(float64(k)) }
+	return discreteEntropy(pmf, 0, len(c.Weights)-1)
+}
+
+func (c CategoricalDist) LogPDF(x float64) float64 {
+	return math.Log(c.PDF(x))
+}
+
+func (c CategoricalDist) LogCDF(x float64) float64 {
+	return math.Log(c.CDF(x))
+}
+
+// Survival sums the weights above x directly rather than taking 1 - CDF.
+func (c CategoricalDist) Survival(x float64) float64 {
+	t := c.total()
+	if math.IsNaN(t) || math.IsNaN(x) {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 0 {
+		return 1
+	}
+	if k >= float64(len(c.Weights)-1) {
+		return 0
+	}
+	sum := 0.0
+	for i := len(c.Weights) - 1; i > int(k); i-- {
+		sum += c.Weights[i]
+	}
+	return sum / t
+}
+
+func (c CategoricalDist) LogSurvival(x float64) float64 {
+	return math.Log(c.Survival(x))
+}
@@ -0,0 +1,124 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// CauchyDist has location X0 and scale Gamma. Its mean, variance and higher
+// moments are undefined and reported as NaN.
+type CauchyDist struct {
+	X0, Gamma float64
+}
+
+// NewCauchy validates and returns CauchyDist{X0: x0, Gamma: gamma}.
+func NewCauchy(x0, gamma float64) (CauchyDist, error) {
+	d := CauchyDist{X0: x0, Gamma: gamma}
+	return d, d.Validate()
+}
+
+func (c CauchyDist) Validate() error {
+	if !finite(c.X0) {
+		return paramError("CauchyDist", "X0", c.X0, "finite")
+	}
+	if !positive(c.Gamma) {
+		return paramError("CauchyDist", "Gamma", c.Gamma, "> 0")
+	}
+	return nil
+}
+
+func (c CauchyDist) Rand() float64 {
+	return c.RandWith(nil)
+}
+
+func (c CauchyDist) RandWith(r *rand.Rand) float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return c.X0 + c.Gamma*math.Tan(math.Pi*(uniform(r)-0.5))
+}
+
+func (c CauchyDist) PDF(x float64) float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	z := (x - c.X0) / c.Gamma
+	return 1 / (math.Pi * c.Gamma * (1 + z*z))
+}
+
+func (c CauchyDist) CDF(x float64) float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return cauchyCDF((x - c.X0) / c.Gamma)
+}
+
+// cauchyCDF evaluates the standard Cauchy CDF, using atan(-1/z)/π in the lower
+// tail where 0.5 + atan(z)/π would cancel.
+func cauchyCDF(z float64) float64 {
+	if z < -1 {
+		return math.Atan(-1/z) / math.Pi
+	}
+	return 0.5 + math.Atan(z)/math.Pi
+}
+
+func (c CauchyDist) Quantile(p float64) float64 {
+	if c.Validate() != nil || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	switch p {
+	case 0:
+		return math.Inf(-1)
+	case 1:
+		return math.Inf(1)
+	}
+	return c.X0 + c.Gamma*math.Tan(math.Pi*(p-0.5))
+}
+
+func (c CauchyDist) Mean() float64       { return math.NaN() }
+func (c CauchyDist) Variance() float64   { return math.NaN() }
+func (c CauchyDist) StdDev() float64     { return math.NaN() }
+func (c CauchyDist) Skewness() float64   { return math.NaN() }
+func (c CauchyDist) ExKurtosis() float64 { return math.NaN() }
+
+func (c CauchyDist) Mode() float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return c.X0
+}
+
+func (c CauchyDist) Median() float64 {
+	return c.Mode()
+}
+
+func (c CauchyDist) Entropy() float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Log(4 * math.Pi * c.Gamma)
+}
+
+func (c CauchyDist) LogPDF(x float64) float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	z := (x - c.X0) / c.Gamma
+	return -math.Log(math.Pi*c.Gamma) - math.Log1p(z*z)
+}
+
+func (c CauchyDist) LogCDF(x float64) float64 {
+	return math.Log(c.CDF(x))
+}
+
+// Survival returns P(X > x) = CDF(2·X0 - x) by symmetry.
+func (c CauchyDist) Survival(x float64) float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return cauchyCDF((c.X0 - x) / c.Gamma)
+}
+
+func (c CauchyDist) LogSurvival(x float64) float64 {
+	return math.Log(c.Survival(x))
+}
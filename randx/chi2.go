@@ -1,38 +1,172 @@
 package randx
 
-import "math"
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/miguelm-revel/revelTools/randx/special"
+)
 
 type Chi2Dist struct {
 	K float64
 }
 
+// NewChi2 validates and returns Chi2Dist{K: k}.
+func NewChi2(k float64) (Chi2Dist, error) {
+	d := Chi2Dist{K: k}
+	return d, d.Validate()
+}
+
+func (c Chi2Dist) Validate() error {
+	if !positive(c.K) {
+		return paramError("Chi2Dist", "K", c.K, "> 0")
+	}
+	return nil
+}
+
 func (c Chi2Dist) Rand() float64 {
-	if c.K <= 0 {
+	return c.RandWith(nil)
+}
+
+func (c Chi2Dist) RandWith(r *rand.Rand) float64 {
+	if c.Validate() != nil {
 		return math.NaN()
 	}
 	shape := c.K / 2.0
 	scale := 2.0
-	return scale * gammaRand(shape)
+	return scale * gammaRand(r, shape)
 }
 
 func (c Chi2Dist) PDF(x float64) float64 {
-	if c.K <= 0 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Exp(c.LogPDF(x))
+}
+
+func (c Chi2Dist) CDF(x float64) float64 {
+	if c.Validate() != nil {
 		return math.NaN()
 	}
 	if x < 0 {
 		return 0
 	}
+	return special.RegLowerGamma(c.K/2.0, x/2.0)
+}
+
+func (c Chi2Dist) Quantile(p float64) float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return 2.0 * special.InvRegLowerGamma(c.K/2.0, p)
+}
+
+func (c Chi2Dist) Mean() float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return c.K
+}
+
+func (c Chi2Dist) Variance() float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return 2 * c.K
+}
+
+func (c Chi2Dist) StdDev() float64 {
+	return math.Sqrt(c.Variance())
+}
+
+func (c Chi2Dist) Skewness() float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Sqrt(8 / c.K)
+}
+
+func (c Chi2Dist) ExKurtosis() float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return 12 / c.K
+}
+
+func (c Chi2Dist) Mode() float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Max(c.K-2, 0)
+}
+
+func (c Chi2Dist) Median() float64 {
+	return c.Quantile(0.5)
+}
+
+func (c Chi2Dist) Entropy() float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
 	a := c.K / 2.0
-	logf := -(a*math.Log(2.0) + logGamma(a)) + (a-1.0)*math.Log(x) - x/2.0
-	return math.Exp(logf)
+	return a + math.Log(2) + special.LogGamma(a) + (1-a)*special.Digamma(a)
 }
 
-func (c Chi2Dist) CDF(x float64) float64 {
-	if c.K <= 0 {
+func (c Chi2Dist) Sample(n int) []float64 {
+	dst := make([]float64, n)
+	c.Fill(dst)
+	return dst
+}
+
+func (c Chi2Dist) Fill(dst []float64) {
+	c.FillWith(nil, dst)
+}
+
+// FillWith computes the Marsaglia–Tsang constants once for the whole batch.
+func (c Chi2Dist) FillWith(r *rand.Rand, dst []float64) {
+	if c.Validate() != nil {
+		fillNaN(dst)
+		return
+	}
+	g := newGammaSampler(c.K / 2.0)
+	for i := range dst {
+		dst[i] = 2.0 * g.sample(r)
+	}
+}
+
+func (c Chi2Dist) LogPDF(x float64) float64 {
+	if c.Validate() != nil {
 		return math.NaN()
 	}
-	if x < 0 {
+	return GammaDist{Shape: c.K / 2.0, Scale: 2.0}.LogPDF(x)
+}
+
+func (c Chi2Dist) LogCDF(x float64) float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return math.Inf(-1)
+	}
+	return special.LogRegLowerGamma(c.K/2.0, x/2.0)
+}
+
+func (c Chi2Dist) Survival(x float64) float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 1
+	}
+	return special.RegUpperGamma(c.K/2.0, x/2.0)
+}
+
+func (c Chi2Dist) LogSurvival(x float64) float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= 0 {
 		return 0
 	}
-	return regLowerGamma(c.K/2.0, x/2.0)
+	return special.LogRegUpperGamma(c.K/2.0, x/2.0)
 }
@@ -0,0 +1,772 @@
+package randx
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// A distribution specification names a registered distribution and gives
+// its parameters, either as a JSON object such as
+//
+//	{"type": "normal", "mu": 0, "sigma": 1}
+//
+// or as compact text listing the parameters in registration order:
+//
+//	normal(0, 1)
+//
+// A trailing slice parameter takes the remaining arguments in text form
+// (categorical(1, 2, 3)) and an array in JSON. Parameters of type Dist nest
+// another specification, e.g. affine(exp(2), 1, 0.5), and a []Dist parameter
+// takes a run of them, as in mixture(normal(0, 1), normal(3, 1), 1, 3).
+// Infinite and NaN values are written as the JSON strings "+Inf", "-Inf" and
+// "NaN", and decoded distributions are validated.
+//
+// EmpiricalDist has no specification: it is built from data rather than
+// parameters, so store the sample itself and rebuild it with NewEmpirical.
+
+// distCodec describes how to encode one registered distribution type.
+type distCodec struct {
+	name   string
+	typ    reflect.Type
+	params []codecParam
+}
+
+type codecParam struct {
+	key      string
+	field    int
+	variadic bool // a trailing []float64
+	dists    bool // a []Dist
+}
+
+var codecs = struct {
+	sync.RWMutex
+	byName map[string]*distCodec
+	byType map[reflect.Type]*distCodec
+}{byName: map[string]*distCodec{}, byType: map[reflect.Type]*distCodec{}}
+
+var (
+	codecName = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	distType  = reflect.TypeFor[Dist]()
+	distsType = reflect.TypeFor[[]Dist]()
+)
+
+// Register makes the struct type D available to ParseDist, UnmarshalDist,
+// MarshalDist and FormatDist under name, with the given parameters in
+// positional order. Each parameter names a field of D, matched without
+// regard to case, of type float64, int, []float64, Dist or []Dist. Only the
+// last may be a []float64, and a []Dist may not be followed by a Dist or
+// []Dist, since in text form it takes every nested specification in a row.
+// Names are lower-case identifiers and may be registered once.
+func Register[D Dist](name string, params ...string) error {
+	typ := reflect.TypeFor[D]()
+	if typ.Kind() != reflect.Struct {
+		return fmt.Errorf("randx: register %s: %v is not a struct", name, typ)
+	}
+	if !codecName.MatchString(name) {
+		return fmt.Errorf("randx: register %q: name must be a lower-case identifier", name)
+	}
+	c := &distCodec{name: name, typ: typ}
+	for i, p := range params {
+		f, ok := typ.FieldByNameFunc(func(s string) bool { return strings.EqualFold(s, p) })
+		if !ok || !f.IsExported() || len(f.Index) != 1 {
+			return fmt.Errorf("randx: register %s: %v has no field %q", name, typ, p)
+		}
+		cp := codecParam{key: strings.ToLower(p), field: f.Index[0]}
+		switch {
+		case f.Type.Kind() == reflect.Float64, f.Type.Kind() == reflect.Int, f.Type == distType:
+		case f.Type == reflect.TypeFor[[]float64]() && i == len(params)-1:
+			cp.variadic = true
+		case f.Type == distsType:
+			cp.dists = true
+		default:
+			return fmt.Errorf("randx: register %s: field %s has unsupported type %v", name, f.Name, f.Type)
+		}
+		if i > 0 && c.params[i-1].dists && (cp.dists || f.Type == distType) {
+			return fmt.Errorf("randx: register %s: field %s follows a []Dist", name, f.Name)
+		}
+		c.params = append(c.params, cp)
+	}
+
+	codecs.Lock()
+	defer codecs.Unlock()
+	if _, dup := codecs.byName[name]; dup {
+		return fmt.Errorf("randx: register %s: name already registered", name)
+	}
+	if old, dup := codecs.byType[typ]; dup {
+		return fmt.Errorf("randx: register %s: %v already registered as %s", name, typ, old.name)
+	}
+	codecs.byName[name] = c
+	codecs.byType[typ] = c
+	return nil
+}
+
+func mustRegister[D Dist](name string, params ...string) {
+	if err := Register[D](name, params...); err != nil {
+		panic(err)
+	}
+}
+
+func init() {
+	mustRegister[BernoulliDist]("bernoulli", "p")
+	mustRegister[BetaDist]("beta", "alpha", "beta")
+	mustRegister[BinomDist]("binom", "n", "p")
+	mustRegister[CategoricalDist]("categorical", "weights")
+	mustRegister[CauchyDist]("cauchy", "x0", "gamma")
+	mustRegister[Chi2Dist]("chi2", "k")
+	mustRegister[DiscreteUniformDist]("discreteuniform", "min", "max")
+	mustRegister[ExpDist]("exp", "lambda")
+	mustRegister[FDist]("f", "d1", "d2")
+	mustRegister[GammaDist]("gamma", "shape", "scale")
+	mustRegister[GeometricDist]("geometric", "p")
+	mustRegister[HypergeometricDist]("hypergeometric", "n", "k", "draws")
+	mustRegister[LogNormalDist]("lognormal", "mu", "sigma")
+	mustRegister[NegBinomialDist]("negbinomial", "r", "p")
+	mustRegister[NormalDist]("normal", "mu", "sigma")
+	mustRegister[ParetoDist]("pareto", "xm", "alpha")
+	mustRegister[PoissonDist]("poisson", "lambda")
+	mustRegister[StudentTDist]("studentt", "nu")
+	mustRegister[UniformDist]("uniform", "min", "max")
+	mustRegister[WeibullDist]("weibull", "k", "lambda")
+	mustRegister[ZipfDist]("zipf", "n", "s")
+	mustRegister[AffineDist]("affine", "d", "loc", "scale")
+	mustRegister[CensoredDist]("censored", "d", "lo", "hi")
+	mustRegister[DiscretizedDist]("discretized", "d")
+	mustRegister[MixtureDist]("mixture", "components", "weights")
+	mustRegister[TruncatedDist]("truncated", "d", "lo", "hi")
+}
+
+func codecFor(d Dist) (*distCodec, error) {
+	codecs.RLock()
+	defer codecs.RUnlock()
+	c, ok := codecs.byType[reflect.TypeOf(d)]
+	if !ok {
+		return nil, fmt.Errorf("randx: %T is not registered", d)
+	}
+	return c, nil
+}
+
+func codecNamed(name string) (*distCodec, error) {
+	codecs.RLock()
+	defer codecs.RUnlock()
+	c, ok := codecs.byName[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("randx: unknown distribution %q", name)
+	}
+	return c, nil
+}
+
+// ParseDist decodes a specification in either JSON or compact text form.
+func ParseDist(spec string) (Dist, error) {
+	if s := strings.TrimSpace(spec); strings.HasPrefix(s, "{") {
+		return UnmarshalDist([]byte(s))
+	}
+	p := textParser{src: spec}
+	d, err := p.dist()
+	if err == nil && p.skipSpace() < len(p.src) {
+		err = p.errorf("unexpected %q", p.src[p.pos:])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// UnmarshalDist decodes a JSON specification.
+func UnmarshalDist(data []byte) (Dist, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, fmt.Errorf("randx: %w", err)
+	}
+	var name string
+	if err := json.Unmarshal(obj["type"], &name); err != nil || name == "" {
+		return nil, errors.New(`randx: specification needs a "type" string`)
+	}
+	c, err := codecNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	v := reflect.New(c.typ).Elem()
+	if err := c.decodeJSON(obj, v); err != nil {
+		return nil, err
+	}
+	return finishDecode(v)
+}
+
+// MarshalDist encodes a registered distribution as a JSON specification.
+func MarshalDist(d Dist) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, d); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// FormatDist encodes a registered distribution in compact text form, which
+// ParseDist reads back.
+func FormatDist(d Dist) (string, error) {
+	var sb strings.Builder
+	if err := writeText(&sb, d); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// Spec holds a Dist that encodes to and decodes from a specification, so a
+// configuration struct can declare a field of type Spec and load it with
+// encoding/json or any decoder that honours encoding.TextUnmarshaler.
+type Spec struct {
+	Dist
+}
+
+// MarshalJSON encodes a nil Dist as null.
+func (s Spec) MarshalJSON() ([]byte, error) {
+	if s.Dist == nil {
+		return []byte("null"), nil
+	}
+	return MarshalDist(s.Dist)
+}
+
+// UnmarshalJSON accepts a JSON specification or a string in text form. Like
+// the standard decoder, it treats null as a no-op.
+func (s *Spec) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	var text string
+	if json.Unmarshal(data, &text) == nil {
+		return s.UnmarshalText([]byte(text))
+	}
+	d, err := UnmarshalDist(data)
+	if err != nil {
+		return err
+	}
+	s.Dist = d
+	return nil
+}
+
+func (s Spec) MarshalText() ([]byte, error) {
+	text, err := FormatDist(s.Dist)
+	return []byte(text), err
+}
+
+func (s *Spec) UnmarshalText(text []byte) error {
+	d, err := ParseDist(string(text))
+	if err != nil {
+		return err
+	}
+	s.Dist = d
+	return nil
+}
+
+// UnmarshalDistInto decodes a JSON specification into dst, a pointer to a
+// registered struct, and validates it. A "type" key, if present, must name
+// that struct. Types registered outside this package use it to implement
+// json.Unmarshaler.
+func UnmarshalDistInto(data []byte, dst Dist) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() {
+		return fmt.Errorf("randx: cannot decode into %T", dst)
+	}
+	v = v.Elem()
+	codecs.RLock()
+	c := codecs.byType[v.Type()]
+	codecs.RUnlock()
+	if c == nil {
+		return fmt.Errorf("randx: %v is not registered", v.Type())
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return fmt.Errorf("randx: %w", err)
+	}
+	if raw, ok := obj["type"]; ok {
+		var name string
+		if err := json.Unmarshal(raw, &name); err != nil || !strings.EqualFold(name, c.name) {
+			return fmt.Errorf("randx: cannot decode type %s into %v", raw, v.Type())
+		}
+	}
+	tmp := reflect.New(c.typ).Elem()
+	if err := c.decodeJSON(obj, tmp); err != nil {
+		return err
+	}
+	if _, err := finishDecode(tmp); err != nil {
+		return err
+	}
+	v.Set(tmp)
+	return nil
+}
+
+func (c *distCodec) decodeJSON(obj map[string]json.RawMessage, v reflect.Value) error {
+	seen := 1 // "type"
+	if _, ok := obj["type"]; !ok {
+		seen = 0
+	}
+	for _, p := range c.params {
+		raw, ok := obj[p.key]
+		if !ok {
+			return fmt.Errorf("randx: %s: missing parameter %q", c.name, p.key)
+		}
+		seen++
+		f := v.Field(p.field)
+		var err error
+		switch {
+		case f.Type() == distType:
+			var inner Dist
+			if inner, err = jsonNested(raw); err == nil {
+				f.Set(reflect.ValueOf(&inner).Elem())
+			}
+		case p.dists:
+			var raws []json.RawMessage
+			if raws, err = jsonArray(raw); err != nil {
+				break
+			}
+			ds := make([]Dist, len(raws))
+			for i := range raws {
+				if ds[i], err = jsonNested(raws[i]); err != nil {
+					break
+				}
+			}
+			f.Set(reflect.ValueOf(ds))
+		case p.variadic:
+			var raws []json.RawMessage
+			if raws, err = jsonArray(raw); err != nil {
+				break
+			}
+			xs := make([]float64, len(raws))
+			for i := range raws {
+				if xs[i], err = jsonFloat(raws[i]); err != nil {
+					break
+				}
+			}
+			f.Set(reflect.ValueOf(xs))
+		default:
+			var x float64
+			if x, err = jsonFloat(raw); err == nil {
+				err = setNumber(f, x)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("randx: %s: parameter %q: %w", c.name, p.key, err)
+		}
+	}
+	if seen != len(obj) {
+		for k := range obj {
+			if k != "type" && !c.has(k) {
+				return fmt.Errorf("randx: %s: unknown parameter %q", c.name, k)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *distCodec) has(key string) bool {
+	for _, p := range c.params {
+		if p.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonNested decodes a nested specification, which may also be given in text
+// form.
+func jsonNested(raw json.RawMessage) (Dist, error) {
+	var inner Spec
+	if err := inner.UnmarshalJSON(raw); err != nil {
+		return nil, err
+	}
+	if inner.Dist == nil {
+		return nil, errors.New("missing specification")
+	}
+	return inner.Dist, nil
+}
+
+// jsonArray decodes a JSON array, rejecting null, which the standard decoder
+// would turn into an empty slice.
+func jsonArray(raw json.RawMessage) ([]json.RawMessage, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(raw, &raws); err != nil {
+		return nil, err
+	}
+	if raws == nil {
+		return nil, errors.New("null is not an array")
+	}
+	return raws, nil
+}
+
+// jsonFloat decodes a JSON number, or one of the strings "+Inf", "-Inf" and
+// "NaN". It rejects null, which the standard decoder would leave as 0.
+func jsonFloat(raw json.RawMessage) (float64, error) {
+	if string(bytes.TrimSpace(raw)) == "null" {
+		return 0, errors.New("null is not a number")
+	}
+	var x float64
+	if err := json.Unmarshal(raw, &x); err == nil {
+		return x, nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return 0, fmt.Errorf("%s is not a number", raw)
+	}
+	x, err := strconv.ParseFloat(s, 64)
+	if err != nil || !(math.IsInf(x, 0) || math.IsNaN(x)) {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+	return x, nil
+}
+
+func setNumber(f reflect.Value, x float64) error {
+	if f.Kind() == reflect.Float64 {
+		f.SetFloat(x)
+		return nil
+	}
+	if x != math.Trunc(x) || math.Abs(x) > 1<<53 {
+		return fmt.Errorf("%v is not an integer", x)
+	}
+	f.SetInt(int64(x))
+	return nil
+}
+
+// finishDecode validates a freshly decoded distribution and fills any cache
+// its constructor would have filled, storing the result back into v.
+func finishDecode(v reflect.Value) (Dist, error) {
+	d := v.Interface().(Dist)
+	if val, ok := d.(Validator); ok {
+		if err := val.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	if c, ok := d.(precomputer); ok {
+		d = c.precompute()
+		v.Set(reflect.ValueOf(d))
+	}
+	return d, nil
+}
+
+// precomputer is implemented by distributions that cache constants derived
+// from their parameters. precompute returns a copy with the cache filled; it
+// is only called on valid distributions.
+type precomputer interface {
+	precompute() Dist
+}
+
+func writeJSON(buf *bytes.Buffer, d Dist) error {
+	c, err := codecFor(d)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(d)
+	fmt.Fprintf(buf, `{"type":%q`, c.name)
+	for _, p := range c.params {
+		fmt.Fprintf(buf, ",%q:", p.key)
+		f := v.Field(p.field)
+		switch {
+		case f.Type() == distType:
+			inner, _ := f.Interface().(Dist)
+			if err := writeJSON(buf, inner); err != nil {
+				return err
+			}
+		case p.dists:
+			buf.WriteByte('[')
+			for i, inner := range f.Interface().([]Dist) {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				if err := writeJSON(buf, inner); err != nil {
+					return err
+				}
+			}
+			buf.WriteByte(']')
+		case p.variadic:
+			buf.WriteByte('[')
+			for i, x := range f.Interface().([]float64) {
+				if i > 0 {
+					buf.WriteByte(',')
+				}
+				writeJSONFloat(buf, x)
+			}
+			buf.WriteByte(']')
+		case f.Kind() == reflect.Int:
+			buf.WriteString(strconv.FormatInt(f.Int(), 10))
+		default:
+			writeJSONFloat(buf, f.Float())
+		}
+	}
+	buf.WriteByte('}')
+	return nil
+}
+
+func writeJSONFloat(buf *bytes.Buffer, x float64) {
+	s := strconv.FormatFloat(x, 'g', -1, 64)
+	if math.IsInf(x, 0) || math.IsNaN(x) {
+		s = strconv.Quote(s)
+	}
+	buf.WriteString(s)
+}
+
+func writeText(sb *strings.Builder, d Dist) error {
+	c, err := codecFor(d)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(d)
+	sb.WriteString(c.name)
+	sb.WriteByte('(')
+	first := true
+	sep := func() {
+		if !first {
+			sb.WriteString(", ")
+		}
+		first = false
+	}
+	for _, p := range c.params {
+		f := v.Field(p.field)
+		switch {
+		case f.Type() == distType:
+			sep()
+			inner, _ := f.Interface().(Dist)
+			if err := writeText(sb, inner); err != nil {
+				return err
+			}
+		case p.dists:
+			for _, inner := range f.Interface().([]Dist) {
+				sep()
+				if err := writeText(sb, inner); err != nil {
+					return err
+				}
+			}
+		case p.variadic:
+			for _, x := range f.Interface().([]float64) {
+				sep()
+				sb.WriteString(strconv.FormatFloat(x, 'g', -1, 64))
+			}
+		case f.Kind() == reflect.Int:
+			sep()
+			sb.WriteString(strconv.FormatInt(f.Int(), 10))
+		default:
+			sep()
+			sb.WriteString(strconv.FormatFloat(f.Float(), 'g', -1, 64))
+		}
+	}
+	sb.WriteByte(')')
+	return nil
+}
+
+// textParser reads the compact form: name(arg, ...), where each argument is
+// a number or a nested specification.
+type textParser struct {
+	src string
+	pos int
+}
+
+func (p *textParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("randx: parse %q at offset %d: %s", p.src, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *textParser) skipSpace() int {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t' || p.src[p.pos] == '\n' || p.src[p.pos] == '\r') {
+		p.pos++
+	}
+	return p.pos
+}
+
+// token returns the next run of characters up to a delimiter.
+func (p *textParser) token() string {
+	start := p.skipSpace()
+	for p.pos < len(p.src) && !strings.ContainsRune("(), \t\n\r", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *textParser) peek() byte {
+	if p.skipSpace() < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+// arg is a parsed argument: a number or a nested distribution.
+type arg struct {
+	x float64
+	d Dist
+}
+
+func (p *textParser) dist() (Dist, error) {
+	name := p.token()
+	if name == "" || p.peek() != '(' {
+		return nil, p.errorf("expected name(arguments)")
+	}
+	c, err := codecNamed(name)
+	if err != nil {
+		return nil, err
+	}
+	p.pos++ // '('
+	var args []arg
+	for p.peek() != ')' {
+		if len(args) > 0 {
+			if p.peek() != ',' {
+				return nil, p.errorf("expected ',' or ')'")
+			}
+			p.pos++
+		}
+		start := p.pos
+		tok := p.token()
+		if p.peek() == '(' {
+			p.pos = start
+			d, err := p.dist()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg{d: d})
+			continue
+		}
+		x, err := strconv.ParseFloat(tok, 64)
+		if err != nil {
+			return nil, p.errorf("%q is not a number", tok)
+		}
+		args = append(args, arg{x: x})
+		if p.pos >= len(p.src) {
+			return nil, p.errorf("missing ')'")
+		}
+	}
+	p.pos++ // ')'
+
+	v := reflect.New(c.typ).Elem()
+	if err := c.decodeArgs(args, v); err != nil {
+		return nil, err
+	}
+	return finishDecode(v)
+}
+
+func (c *distCodec) decodeArgs(args []arg, v reflect.Value) error {
+	j := 0
+	for _, p := range c.params {
+		f := v.Field(p.field)
+		switch {
+		case p.dists:
+			var ds []Dist
+			for ; j < len(args) && args[j].d != nil; j++ {
+				ds = append(ds, args[j].d)
+			}
+			f.Set(reflect.ValueOf(ds))
+		case p.variadic:
+			xs := make([]float64, 0, len(args)-j)
+			for ; j < len(args); j++ {
+				if args[j].d != nil {
+					return fmt.Errorf("randx: %s: parameter %q takes numbers", c.name, p.key)
+				}
+				xs = append(xs, args[j].x)
+			}
+			f.Set(reflect.ValueOf(xs))
+		default:
+			if j == len(args) {
+				return fmt.Errorf("randx: %s takes %d arguments, got %d", c.name, len(c.params), len(args))
+			}
+			a := args[j]
+			j++
+			if (a.d != nil) != (f.Type() == distType) {
+				return fmt.Errorf("randx: %s: wrong kind of argument for %q", c.name, p.key)
+			}
+			if a.d != nil {
+				f.Set(reflect.ValueOf(&a.d).Elem())
+				continue
+			}
+			if err := setNumber(f, a.x); err != nil {
+				return fmt.Errorf("randx: %s: parameter %q: %w", c.name, p.key, err)
+			}
+		}
+	}
+	if j < len(args) {
+		return fmt.Errorf("randx: %s takes %d arguments, got %d", c.name, len(c.params), len(args))
+	}
+	return nil
+}
+
+// The built-in distributions encode themselves as specifications, so they
+// can be used directly as fields of JSON configuration structs.
+
+func (b BernoulliDist) MarshalJSON() ([]byte, error)     { return MarshalDist(b) }
+func (b *BernoulliDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, b) }
+
+func (b BetaDist) MarshalJSON() ([]byte, error)     { return MarshalDist(b) }
+func (b *BetaDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, b) }
+
+func (b BinomDist) MarshalJSON() ([]byte, error)     { return MarshalDist(b) }
+func (b *BinomDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, b) }
+
+func (c CategoricalDist) MarshalJSON() ([]byte, error)     { return MarshalDist(c) }
+func (c *CategoricalDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, c) }
+
+func (c CauchyDist) MarshalJSON() ([]byte, error)     { return MarshalDist(c) }
+func (c *CauchyDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, c) }
+
+func (c Chi2Dist) MarshalJSON() ([]byte, error)     { return MarshalDist(c) }
+func (c *Chi2Dist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, c) }
+
+func (d DiscreteUniformDist) MarshalJSON() ([]byte, error)     { return MarshalDist(d) }
+func (d *DiscreteUniformDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, d) }
+
+func (e ExpDist) MarshalJSON() ([]byte, error)     { return MarshalDist(e) }
+func (e *ExpDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, e) }
+
+func (f FDist) MarshalJSON() ([]byte, error)     { return MarshalDist(f) }
+func (f *FDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, f) }
+
+func (g GammaDist) MarshalJSON() ([]byte, error)     { return MarshalDist(g) }
+func (g *GammaDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, g) }
+
+func (g GeometricDist) MarshalJSON() ([]byte, error)     { return MarshalDist(g) }
+func (g *GeometricDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, g) }
+
+func (h HypergeometricDist) MarshalJSON() ([]byte, error)     { return MarshalDist(h) }
+func (h *HypergeometricDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, h) }
+
+func (l LogNormalDist) MarshalJSON() ([]byte, error)     { return MarshalDist(l) }
+func (l *LogNormalDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, l) }
+
+func (n NegBinomialDist) MarshalJSON() ([]byte, error)     { return MarshalDist(n) }
+func (n *NegBinomialDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, n) }
+
+func (n NormalDist) MarshalJSON() ([]byte, error)     { return MarshalDist(n) }
+func (n *NormalDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, n) }
+
+func (p ParetoDist) MarshalJSON() ([]byte, error)     { return MarshalDist(p) }
+func (p *ParetoDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, p) }
+
+func (p PoissonDist) MarshalJSON() ([]byte, error)     { return MarshalDist(p) }
+func (p *PoissonDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, p) }
+
+func (s StudentTDist) MarshalJSON() ([]byte, error)     { return MarshalDist(s) }
+func (s *StudentTDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, s) }
+
+func (u UniformDist) MarshalJSON() ([]byte, error)     { return MarshalDist(u) }
+func (u *UniformDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, u) }
+
+func (w WeibullDist) MarshalJSON() ([]byte, error)     { return MarshalDist(w) }
+func (w *WeibullDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, w) }
+
+func (z ZipfDist) MarshalJSON() ([]byte, error)     { return MarshalDist(z) }
+func (z *ZipfDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, z) }
+
+func (a AffineDist) MarshalJSON() ([]byte, error)     { return MarshalDist(a) }
+func (a *AffineDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, a) }
+
+func (c CensoredDist) MarshalJSON() ([]byte, error)     { return MarshalDist(c) }
+func (c *CensoredDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, c) }
+
+func (d DiscretizedDist) MarshalJSON() ([]byte, error)     { return MarshalDist(d) }
+func (d *DiscretizedDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, d) }
+
+func (t TruncatedDist) MarshalJSON() ([]byte, error)     { return MarshalDist(t) }
+func (t *TruncatedDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, t) }
+
+func (m MixtureDist) MarshalJSON() ([]byte, error)     { return MarshalDist(m) }
+func (m *MixtureDist) UnmarshalJSON(data []byte) error { return UnmarshalDistInto(data, m) }
@@ -0,0 +1,217 @@
+package randx
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDist_Forms(t *testing.T) {
+	cases := []struct {
+		spec string
+		want Dist
+	}{
+		{`{"type":"normal","mu":0,"sigma":1}`, NormalDist{Mu: 0, Sigma: 1}},
+		{"exp(0.5)", ExpDist{Lambda: 0.5}},
+		{" poisson( 12 ) ", PoissonDist{Lambda: 12}},
+		{"Binom(10, 0.25)", BinomDist{N: 10, P: 0.25}},
+		{"categorical(1, 2, 3.5)", CategoricalDist{Weights: []float64{1, 2, 3.5}}},
+		{"affine(exp(2), 1, 0.5)", AffineDist{D: ExpDist{Lambda: 2}, Loc: 1, Scale: 0.5}},
+		{"censored(normal(0, 1), -inf, 2)", CensoredDist{D: NormalDist{Mu: 0, Sigma: 1}, Lo: math.Inf(-1), Hi: 2}},
+		{`{"type":"censored","d":{"type":"exp","lambda":1},"lo":"-Inf","hi":3}`,
+			CensoredDist{D: ExpDist{Lambda: 1}, Lo: math.Inf(-1), Hi: 3}},
+		{`{"type":"discretized","d":"exp(1)"}`, DiscretizedDist{D: ExpDist{Lambda: 1}}},
+		{"mixture(normal(0, 1), exp(2), 1, 3)", MixtureDist{
+			Components: []Dist{NormalDist{Mu: 0, Sigma: 1}, ExpDist{Lambda: 2}}, Weights: []float64{1, 3}}},
+		{`{"type":"mixture","components":["exp(1)",{"type":"exp","lambda":2}],"weights":[1,1]}`, MixtureDist{
+			Components: []Dist{ExpDist{Lambda: 1}, ExpDist{Lambda: 2}}, Weights: []float64{1, 1}}},
+	}
+	for _, c := range cases {
+		got, err := ParseDist(c.spec)
+		if err != nil {
+			t.Errorf("ParseDist(%q): %v", c.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("ParseDist(%q) = %#v, want %#v", c.spec, got, c.want)
+		}
+	}
+}
+
+func TestParseDist_RoundTrip(t *testing.T) {
+	// Decoding fills the Zipf normaliser cache, as NewZipf does.
+	zipf, err := NewZipf(100, 1.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dists := []Dist{
+		BernoulliDist{P: 0.3}, BetaDist{Alpha: 2, Beta: 0.5}, BinomDist{N: 7, P: 0.1},
+		CategoricalDist{Weights: []float64{0.2, 0.8}}, CauchyDist{X0: -1, Gamma: 2}, Chi2Dist{K: 3},
+		DiscreteUniformDist{Min: -2, Max: 5}, ExpDist{Lambda: 1.5}, FDist{D1: 3, D2: 9},
+		GammaDist{Shape: 2.5, Scale: 0.1}, GeometricDist{P: 0.4}, HypergeometricDist{N: 20, K: 7, Draws: 5},
+		LogNormalDist{Mu: 0.1, Sigma: 0.2}, NegBinomialDist{R: 3, P: 0.6}, NormalDist{Mu: -3, Sigma: 1e-3},
+		ParetoDist{Xm: 1, Alpha: 2}, PoissonDist{Lambda: 0}, StudentTDist{Nu: 4}, UniformDist{Min: 0, Max: 1},
+		WeibullDist{K: 1.5, Lambda: 2}, zipf,
+		AffineDist{D: AffineDist{D: GammaDist{Shape: 2, Scale: 1}, Loc: 0, Scale: 3}, Loc: 1, Scale: 1},
+		CensoredDist{D: PoissonDist{Lambda: 4}, Lo: 1, Hi: math.Inf(1)},
+		DiscretizedDist{D: ExpDist{Lambda: 1.0 / 3}}, TruncatedDist{D: PoissonDist{Lambda: 3}, Lo: 2, Hi: math.Inf(1)},
+		MixtureDist{Components: []Dist{NormalDist{Mu: -1, Sigma: 1}, AffineDist{D: ExpDist{Lambda: 1}, Loc: 2, Scale: 1}}, Weights: []float64{0.25, 0.75}},
+	}
+	for _, d := range dists {
+		text, err := FormatDist(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := ParseDist(text); err != nil || !reflect.DeepEqual(got, d) {
+			t.Errorf("text %q decoded to %#v, %v; want %#v", text, got, err, d)
+		}
+		data, err := json.Marshal(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := ParseDist(string(data)); err != nil || !reflect.DeepEqual(got, d) {
+			t.Errorf("JSON %s decoded to %#v, %v; want %#v", data, got, err, d)
+		}
+		// The concrete type decodes its own JSON.
+		ptr := reflect.New(reflect.TypeOf(d))
+		if err := json.Unmarshal(data, ptr.Interface()); err != nil || !reflect.DeepEqual(ptr.Elem().Interface(), d) {
+			t.Errorf("json.Unmarshal(%s) into %T = %v, %v", data, d, ptr.Elem().Interface(), err)
+		}
+	}
+	if text, _ := FormatDist(NormalDist{Mu: 0, Sigma: 1}); text != "normal(0, 1)" {
+		t.Errorf("FormatDist = %q", text)
+	}
+	if data, _ := json.Marshal(NormalDist{Mu: 0, Sigma: 1}); string(data) != `{"type":"normal","mu":0,"sigma":1}` {
+		t.Errorf("json.Marshal = %s", data)
+	}
+}
+
+func TestSpec_Config(t *testing.T) {
+	var cfg struct {
+		Arrivals Spec    `json:"arrivals"`
+		Service  Spec    `json:"service"`
+		Fixed    ExpDist `json:"fixed"`
+	}
+	in := `{"arrivals": "poisson(12)", "service": {"type": "gamma", "shape": 2, "scale": 0.5},
+		"fixed": {"lambda": 4}}`
+	if err := json.Unmarshal([]byte(in), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Arrivals.Dist != (PoissonDist{Lambda: 12}) || cfg.Service.Dist != (GammaDist{Shape: 2, Scale: 0.5}) ||
+		cfg.Fixed != (ExpDist{Lambda: 4}) {
+		t.Errorf("decoded %+v", cfg)
+	}
+	if x := cfg.Service.RandWith(NewRand(1)); !(x > 0) {
+		t.Errorf("sample %v", x)
+	}
+	out, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"arrivals":{"type":"poisson","lambda":12},"service":{"type":"gamma","shape":2,"scale":0.5},` +
+		`"fixed":{"type":"exp","lambda":4}}`
+	if string(out) != want {
+		t.Errorf("json.Marshal = %s, want %s", out, want)
+	}
+	if text, err := (Spec{ExpDist{Lambda: 2}}).MarshalText(); err != nil || string(text) != "exp(2)" {
+		t.Errorf("MarshalText = %q, %v", text, err)
+	}
+
+	// null leaves the Spec unset, and an unset Spec encodes as null.
+	var opt struct {
+		Jitter Spec `json:"jitter"`
+	}
+	if err := json.Unmarshal([]byte(`{"jitter": null}`), &opt); err != nil || opt.Jitter.Dist != nil {
+		t.Errorf("null decoded to %+v, %v", opt.Jitter, err)
+	}
+	if out, err := json.Marshal(opt); err != nil || string(out) != `{"jitter":null}` {
+		t.Errorf("json.Marshal = %s, %v", out, err)
+	}
+}
+
+func TestParseDist_Errors(t *testing.T) {
+	for _, spec := range []string{
+		"", "normal", "normal(0, 1", "normal(0 1)", "normal(0, 1) extra", "normal(0, 1, 2)",
+		"normal(0)", "nosuch(1)", "binom(2.5, 0.5)", "exp(x)", "categorical()", "affine(1, 2, 3)",
+		"exp(normal(0, 1))", "categorical(1, exp(1))",
+		`{"mu": 0}`, `{"type": "normal", "mu": 0}`, `{"type": "normal", "mu": 0, "sigma": 1, "rho": 2}`,
+		`{"type": "normal", "mu": "zero", "sigma": 1}`, `{"type": "exp", "lambda": "1e3"}`, `[1, 2]`,
+		`{"type": "affine", "d": null, "loc": 0, "scale": 1}`,
+		"mixture(1, normal(0, 1))", `{"type": "mixture", "components": [null], "weights": [1]}`,
+		`{"type": "normal", "mu": null, "sigma": 1}`, `{"type": "categorical", "weights": null}`,
+		`{"type": "categorical", "weights": [1, null]}`, `{"type": "mixture", "components": null, "weights": []}`,
+	} {
+		if d, err := ParseDist(spec); err == nil {
+			t.Errorf("ParseDist(%q) = %#v, want an error", spec, d)
+		}
+	}
+	// Decoded distributions are validated.
+	for _, spec := range []string{"exp(-1)", `{"type": "normal", "mu": 0, "sigma": 0}`, "affine(exp(0), 0, 1)"} {
+		if _, err := ParseDist(spec); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("ParseDist(%q) error = %v, want ErrInvalidParameter", spec, err)
+		}
+	}
+	var e ExpDist
+	if err := json.Unmarshal([]byte(`{"type": "normal", "lambda": 1}`), &e); err == nil {
+		t.Error("decoding a normal spec into ExpDist succeeded")
+	}
+	if _, err := FormatDist(plainDist{}); err == nil {
+		t.Error("formatting an unregistered type succeeded")
+	}
+}
+
+// shiftedExp is a custom distribution registered by the tests.
+type shiftedExp struct {
+	Rate, Offset float64
+}
+
+func (s shiftedExp) Rand() float64 { return s.RandWith(nil) }
+func (s shiftedExp) RandWith(r *rand.Rand) float64 {
+	return s.Offset + ExpDist{Lambda: s.Rate}.RandWith(r)
+}
+func (s shiftedExp) PDF(x float64) float64 { return ExpDist{Lambda: s.Rate}.PDF(x - s.Offset) }
+func (s shiftedExp) CDF(x float64) float64 { return ExpDist{Lambda: s.Rate}.CDF(x - s.Offset) }
+
+func TestRegister(t *testing.T) {
+	if err := Register[shiftedExp]("shiftedexp", "rate", "offset"); err != nil {
+		t.Fatal(err)
+	}
+	d, err := ParseDist("affine(shiftedexp(2, 10), 0, 3)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (AffineDist{D: shiftedExp{Rate: 2, Offset: 10}, Loc: 0, Scale: 3}); d != want {
+		t.Errorf("got %#v, want %#v", d, want)
+	}
+	if data, _ := MarshalDist(shiftedExp{Rate: 1, Offset: -1}); string(data) != `{"type":"shiftedexp","rate":1,"offset":-1}` {
+		t.Errorf("MarshalDist = %s", data)
+	}
+
+	for name, err := range map[string]error{
+		"duplicate name":    Register[shiftedExp]("exp", "rate"),
+		"duplicate type":    Register[shiftedExp]("other", "rate"),
+		"bad name":          Register[shiftedExp]("Shifted Exp", "rate"),
+		"missing field":     Register[shiftedExp]("shifted2", "scale"),
+		"unsupported field": Register[labelled]("labelled", "name"),
+		"slice not last":    Register[labelled]("labelled", "p", "rate"),
+		"promoted field":    Register[labelled]("labelled", "rate"),
+		"dist after dists":  Register[labelled]("labelled", "parts", "base"),
+	} {
+		if err == nil || !strings.HasPrefix(err.Error(), "randx: register") {
+			t.Errorf("%s: error = %v", name, err)
+		}
+	}
+}
+
+// labelled has fields that Register must reject.
+type labelled struct {
+	shiftedExp
+	Name  string
+	P     []float64
+	Parts []Dist
+	Base  Dist
+}
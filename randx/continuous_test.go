@@ -0,0 +1,198 @@
+package randx
+
+import (
+	"math"
+	"testing"
+)
+
+type fullDist interface {
+	Dist
+	Quantiler
+	Moments
+}
+
+var (
+	_ fullDist = GammaDist{}
+	_ fullDist = BetaDist{}
+	_ fullDist = UniformDist{}
+	_ fullDist = LogNormalDist{}
+	_ fullDist = StudentTDist{}
+	_ fullDist = FDist{}
+	_ fullDist = WeibullDist{}
+	_ fullDist = ParetoDist{}
+	_ fullDist = CauchyDist{}
+)
+
+// simpson integrates f over [a, b] with the composite Simpson rule.
+func simpson(f func(float64) float64, a, b float64, n int) float64 {
+	h := (b - a) / float64(n)
+	sum := f(a) + f(b)
+	for i := 1; i < n; i++ {
+		w := 2.0
+		if i%2 == 1 {
+			w = 4.0
+		}
+		sum += w * f(a+float64(i)*h)
+	}
+	return sum * h / 3
+}
+
+func TestContinuous_MomentsMatchDensity(t *testing.T) {
+	dists := map[string]fullDist{
+		"gamma":     GammaDist{Shape: 2.5, Scale: 1.5},
+		"beta":      BetaDist{Alpha: 2, Beta: 3.5},
+		"uniform":   UniformDist{Min: -1, Max: 3},
+		"lognormal": LogNormalDist{Mu: 0.3, Sigma: 0.5},
+		"student-t": StudentTDist{Nu: 9},
+		"f":         FDist{D1: 5, D2: 20},
+		"weibull":   WeibullDist{K: 1.7, Lambda: 2},
+		"pareto":    ParetoDist{Xm: 1, Alpha: 9},
+	}
+	for name, d := range dists {
+		lo, hi := d.Quantile(1e-13), d.Quantile(1-1e-13)
+		moment := func(g func(float64) float64) float64 {
+			return simpson(func(x float64) float64 { return g(x) * d.PDF(x) }, lo, hi, 400000)
+		}
+		mean := moment(func(x float64) float64 { return x })
+		central := func(k float64) float64 {
+			return moment(func(x float64) float64 { return math.Pow(x-mean, k) })
+		}
+		v := central(2)
+		entropy := moment(func(x float64) float64 {
+			if p := d.PDF(x); p > 0 {
+				return -math.Log(p)
+			}
+			return 0
+		})
+		// Truncating heavy tails at the 1e-13 quantiles costs the fourth
+		// moment a few parts in 1e5, hence its looser tolerance.
+		checks := []struct {
+			what           string
+			got, want, tol float64
+		}{
+			{"mass", moment(func(float64) float64 { return 1 }), 1, 1e-6},
+			{"mean", d.Mean(), mean, 1e-6},
+			{"variance", d.Variance(), v, 1e-6},
+			{"skewness", d.Skewness(), central(3) / math.Pow(v, 1.5), 1e-6},
+			{"excess kurtosis", d.ExKurtosis(), central(4)/(v*v) - 3, 1e-4},
+			{"entropy", d.Entropy(), entropy, 1e-6},
+			{"median", d.CDF(d.Median()), 0.5, 1e-6},
+		}
+		for _, c := range checks {
+			if math.Abs(c.got-c.want) > c.tol*math.Max(1, math.Abs(c.want)) {
+				t.Errorf("%s %s: got %v, want %v", name, c.what, c.got, c.want)
+			}
+		}
+		mode := d.Mode()
+		if d.PDF(mode) < d.PDF(mode-1e-4) || d.PDF(mode) < d.PDF(mode+1e-4) {
+			t.Errorf("%s: Mode() = %v is not a local maximum of the PDF", name, mode)
+		}
+	}
+}
+
+func TestContinuous_QuantileAndSampling(t *testing.T) {
+	dists := map[string]fullDist{
+		"gamma k<1": GammaDist{Shape: 0.4, Scale: 2},
+		"gamma":     GammaDist{Shape: 7, Scale: 0.5},
+		"beta":      BetaDist{Alpha: 0.5, Beta: 0.7},
+		"beta wide": BetaDist{Alpha: 40, Beta: 8},
+		"uniform":   UniformDist{Min: 2, Max: 5},
+		"lognormal": LogNormalDist{Mu: -1, Sigma: 1.2},
+		"student-t": StudentTDist{Nu: 2.5},
+		"cauchy-t":  StudentTDist{Nu: 1},
+		"f":         FDist{D1: 3, D2: 7},
+		"weibull":   WeibullDist{K: 0.6, Lambda: 3},
+		"pareto":    ParetoDist{Xm: 2, Alpha: 1.5},
+		"cauchy":    CauchyDist{X0: -2, Gamma: 0.5},
+	}
+	const n = 100000
+	for name, d := range dists {
+		for _, p := range []float64{1e-6, 0.01, 0.3, 0.5, 0.9, 1 - 1e-6} {
+			if got := d.CDF(d.Quantile(p)); math.Abs(got-p) > 1e-9 {
+				t.Errorf("%s: CDF(Quantile(%v)) = %v", name, p, got)
+			}
+		}
+		xs := make([]float64, n)
+		FillWith(d, NewRand(21), xs)
+		if ks := ksStatistic(xs, d.CDF); ks > ksCritical(n) {
+			t.Errorf("%s: KS distance %v exceeds %v", name, ks, ksCritical(n))
+		}
+	}
+}
+
+func TestBeta_SmallShapesSampler(t *testing.T) {
+	// Both gamma variates underflow to zero for shapes this small, so the
+	// sampler must work in log space to avoid 0/0.
+	for _, d := range []BetaDist{{Alpha: 0.001, Beta: 0.001}, {Alpha: 0.001, Beta: 0.01}} {
+		const n = 20000
+		xs := make([]float64, n)
+		d.FillWith(NewRand(5), xs)
+		r := NewRand(6)
+		for i := 0; i < n/2; i++ {
+			xs[i] = d.RandWith(r)
+		}
+		sum := 0.0
+		for _, x := range xs {
+			if !(x >= 0 && x <= 1) {
+				t.Fatalf("%+v: sample %v outside [0, 1]", d, x)
+			}
+			sum += x
+		}
+		se := math.Sqrt(d.Variance() / n)
+		if mean := sum / n; math.Abs(mean-d.Mean()) > 5*se {
+			t.Errorf("%+v: sample mean %v, want %v", d, mean, d.Mean())
+		}
+	}
+}
+
+func TestStudentT_KnownQuantiles(t *testing.T) {
+	cases := []struct {
+		nu, p, want float64
+	}{
+		{1, 0.975, 12.706204736174698},
+		{10, 0.975, 2.2281388519862744},
+		{30, 0.95, 1.6972608943617378},
+	}
+	for _, c := range cases {
+		if got := (StudentTDist{Nu: c.nu}).Quantile(c.p); math.Abs(got-c.want) > 1e-8 {
+			t.Errorf("t(%v).Quantile(%v) = %v, want %v", c.nu, c.p, got, c.want)
+		}
+	}
+}
+
+// continuousEdgeCases covers each density formula, including the shapes whose
+// density is infinite or nonzero at the origin.
+var continuousEdgeCases = map[string]Dist{
+	"gamma":     GammaDist{Shape: 2.5, Scale: 1.5},
+	"gamma k<1": GammaDist{Shape: 0.5, Scale: 1.5},
+	"chi2":      Chi2Dist{K: 4},
+	"beta":      BetaDist{Alpha: 2, Beta: 3.5},
+	"uniform":   UniformDist{Min: -1, Max: 3},
+	"lognormal": LogNormalDist{Mu: 0.3, Sigma: 0.5},
+	"student-t": StudentTDist{Nu: 9},
+	"f":         FDist{D1: 5, D2: 20},
+	"f d1=1":    FDist{D1: 1, D2: 20},
+	"weibull":   WeibullDist{K: 1.7, Lambda: 2},
+	"weibull<1": WeibullDist{K: 0.5, Lambda: 2},
+	"pareto":    ParetoDist{Xm: 1, Alpha: 9},
+	"cauchy":    CauchyDist{X0: -2, Gamma: 0.5},
+}
+
+func TestContinuous_NonFiniteArguments(t *testing.T) {
+	for name, d := range continuousEdgeCases {
+		if got := d.PDF(math.NaN()); !math.IsNaN(got) {
+			t.Errorf("%s: PDF(NaN) = %v, want NaN", name, got)
+		}
+		if got := LogPDF(d, math.NaN()); !math.IsNaN(got) {
+			t.Errorf("%s: LogPDF(NaN) = %v, want NaN", name, got)
+		}
+		for _, x := range []float64{math.Inf(1), math.Inf(-1)} {
+			if got := d.PDF(x); got != 0 {
+				t.Errorf("%s: PDF(%v) = %v, want 0", name, x, got)
+			}
+			if got := LogPDF(d, x); !math.IsInf(got, -1) {
+				t.Errorf("%s: LogPDF(%v) = %v, want -Inf", name, x, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package randx
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/miguelm-revel/revelTools/randx/special"
+)
+
+// DirichletDist is the Dirichlet distribution over the probability simplex
+// with concentration parameters Alpha.
+type DirichletDist struct {
+	Alpha []float64
+}
+
+// NewDirichlet validates and returns DirichletDist{Alpha: alpha}. The
+// concentrations are copied.
+func NewDirichlet(alpha []float64) (DirichletDist, error) {
+	d := DirichletDist{Alpha: slices.Clone(alpha)}
+	return d, d.Validate()
+}
+
+func (d DirichletDist) Validate() error {
+	if len(d.Alpha) == 0 {
+		return paramError("DirichletDist", "Alpha", 0, "at least one concentration")
+	}
+	for i, a := range d.Alpha {
+		if !positive(a) {
+			return paramError("DirichletDist", fmt.Sprintf("Alpha[%d]", i), a, "> 0")
+		}
+	}
+	return nil
+}
+
+func (d DirichletDist) Dim() int {
+	return len(d.Alpha)
+}
+
+func (d DirichletDist) Rand() []float64 {
+	return d.RandWith(nil)
+}
+
+// RandWith normalizes independent Gamma(αᵢ, 1) variates. They are drawn and
+// normalized in log space, since for small αᵢ every draw can underflow to 0.
+func (d DirichletDist) RandWith(r *rand.Rand) []float64 {
+	x := make([]float64, len(d.Alpha))
+	if d.Validate() != nil {
+		fillNaN(x)
+		return x
+	}
+	for i, a := range d.Alpha {
+		x[i] = logGammaRand(r, a)
+	}
+	lsum := special.LogSumExp(x)
+	for i := range x {
+		x[i] = math.Exp(x[i] - lsum)
+	}
+	return x
+}
+
+func (d DirichletDist) LogPDF(x []float64) float64 {
+	if d.Validate() != nil || len(x) != len(d.Alpha) {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, xi := range x {
+		if xi < 0 || xi > 1 {
+			return math.Inf(-1)
+		}
+		sum += xi
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		return math.Inf(-1)
+	}
+
+	alpha0 := 0.0
+	logf := 0.0
+	for i, a := range d.Alpha {
+		alpha0 += a
+		logf -= special.LogGamma(a)
+		// With αᵢ = 1 the factor xᵢ^0 is 1 even on the boundary xᵢ = 0,
+		// where (αᵢ-1)·ln xᵢ would be 0·-Inf = NaN.
+		if a != 1 {
+			logf += (a - 1) * math.Log(x[i])
+		}
+	}
+	return logf + special.LogGamma(alpha0)
+}
+
+// Mean returns αᵢ / Σα for every component.
+func (d DirichletDist) Mean() []float64 {
+	m := make([]float64, len(d.Alpha))
+	if d.Validate() != nil {
+		fillNaN(m)
+		return m
+	}
+	alpha0 := 0.0
+	for _, a := range d.Alpha {
+		alpha0 += a
+	}
+	for i, a := range d.Alpha {
+		m[i] = a / alpha0
+	}
+	return m
+}
@@ -0,0 +1,242 @@
+package randx
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+var (
+	_ fullDist = BernoulliDist{}
+	_ fullDist = GeometricDist{}
+	_ fullDist = NegBinomialDist{}
+	_ fullDist = HypergeometricDist{}
+	_ fullDist = DiscreteUniformDist{}
+	_ fullDist = ZipfDist{}
+	_ fullDist = CategoricalDist{}
+)
+
+// chiSquareFit returns Pearson's statistic for the integer samples xs against
+// d, and the upper 1e-6 critical value for it. The central 1 - 2e-4 of the
+// mass is binned per value and the remaining tails are pooled.
+func chiSquareFit(d interface {
+	Dist
+	Quantiler
+}, xs []float64) (stat, crit float64) {
+	counts := map[int]int{}
+	for _, x := range xs {
+		counts[int(x)]++
+	}
+	n := float64(len(xs))
+	lo := int(d.Quantile(1e-4))
+	hi := int(d.Quantile(1 - 1e-4))
+	bins := 0
+	for k := lo; k <= hi; k++ {
+		var obs int
+		var exp float64
+		switch k {
+		case lo:
+			for j, c := range counts {
+				if j <= lo {
+					obs += c
+				}
+			}
+			exp = n * d.CDF(float64(lo))
+		case hi:
+			for j, c := range counts {
+				if j >= hi {
+					obs += c
+				}
+			}
+			exp = n * (1 - d.CDF(float64(hi-1)))
+		default:
+			obs = counts[k]
+			exp = n * d.PDF(float64(k))
+		}
+		if exp == 0 {
+			if obs != 0 {
+				return math.Inf(1), 0
+			}
+			continue
+		}
+		diff := float64(obs) - exp
+		stat += diff * diff / exp
+		bins++
+	}
+	if bins < 2 {
+		return 0, 0
+	}
+	return stat, Chi2Dist{K: float64(bins - 1)}.Quantile(1 - 1e-6)
+}
+
+var discreteCases = map[string]fullDist{
+	"bernoulli":      BernoulliDist{P: 0.3},
+	"geometric":      GeometricDist{P: 0.2},
+	"neg binomial":   NegBinomialDist{R: 3.5, P: 0.4},
+	"hypergeometric": HypergeometricDist{N: 60, K: 25, Draws: 18},
+	"discrete unif":  DiscreteUniformDist{Min: -3, Max: 9},
+	"zipf":           ZipfDist{N: 50, S: 1.2},
+	"zipf s=1":       ZipfDist{N: 1000, S: 1},
+	"zipf s<1":       ZipfDist{N: 200, S: 0.6},
+	"categorical":    CategoricalDist{Weights: []float64{2, 0, 5, 1, 0.5}},
+}
+
+func TestDiscrete_MomentsMatchPMF(t *testing.T) {
+	for name, d := range discreteCases {
+		lo := int(d.Quantile(0)) - 1
+		hi := int(d.Quantile(1-1e-15)) + 1
+		var mass, m1 float64
+		for k := lo; k <= hi; k++ {
+			p := d.PDF(float64(k))
+			mass += p
+			m1 += p * float64(k)
+			if cdf := d.CDF(float64(k)); math.Abs(cdf-mass) > 1e-9 {
+				t.Fatalf("%s: CDF(%d) = %v, want %v", name, k, cdf, mass)
+			}
+		}
+		var m2, m3, m4, h float64
+		for k := lo; k <= hi; k++ {
+			p := d.PDF(float64(k))
+			dk := float64(k) - m1
+			m2 += p * dk * dk
+			m3 += p * dk * dk * dk
+			m4 += p * dk * dk * dk * dk
+			if p > 0 {
+				h -= p * math.Log(p)
+			}
+		}
+		checks := []struct {
+			what      string
+			got, want float64
+		}{
+			{"mass", mass, 1},
+			{"mean", d.Mean(), m1},
+			{"variance", d.Variance(), m2},
+			{"skewness", d.Skewness(), m3 / math.Pow(m2, 1.5)},
+			{"excess kurtosis", d.ExKurtosis(), m4/(m2*m2) - 3},
+			{"entropy", d.Entropy(), h},
+		}
+		for _, c := range checks {
+			if math.Abs(c.got-c.want) > 1e-8*math.Max(1, math.Abs(c.want)) {
+				t.Errorf("%s %s: got %v, want %v", name, c.what, c.got, c.want)
+			}
+		}
+		mode := d.Mode()
+		for k := lo; k <= hi; k++ {
+			if d.PDF(float64(k)) > d.PDF(mode) {
+				t.Errorf("%s: Mode() = %v but PMF(%d) is larger", name, mode, k)
+				break
+			}
+		}
+		for _, p := range []float64{0.01, 0.25, 0.5, 0.8, 0.999} {
+			k := d.Quantile(p)
+			if d.CDF(k) < p || d.CDF(k-1) >= p {
+				t.Errorf("%s: Quantile(%v) = %v is not the smallest k with CDF(k) >= p", name, p, k)
+			}
+		}
+	}
+}
+
+func TestDiscrete_SamplerFitsPMF(t *testing.T) {
+	const draws = 200000
+	for name, d := range discreteCases {
+		xs := make([]float64, draws)
+		FillWith(d, NewRand(17), xs)
+		if stat, crit := chiSquareFit(d, xs); stat > crit {
+			t.Errorf("%s: chi-square %v exceeds %v", name, stat, crit)
+		}
+	}
+}
+
+func TestDiscrete_NonFiniteArguments(t *testing.T) {
+	for name, d := range discreteCases {
+		if got := d.CDF(math.NaN()); !math.IsNaN(got) {
+			t.Errorf("%s: CDF(NaN) = %v, want NaN", name, got)
+		}
+		if got := d.CDF(math.Inf(1)); got != 1 {
+			t.Errorf("%s: CDF(+Inf) = %v, want 1", name, got)
+		}
+		if got := d.CDF(math.Inf(-1)); got != 0 {
+			t.Errorf("%s: CDF(-Inf) = %v, want 0", name, got)
+		}
+	}
+}
+
+func TestDiscreteUniform_FullIntRange(t *testing.T) {
+	d := DiscreteUniformDist{Min: math.MinInt, Max: math.MaxInt}
+	r := NewRand(3)
+	neg := 0
+	for range 1000 {
+		if d.RandWith(r) < 0 {
+			neg++
+		}
+	}
+	if neg < 400 || neg > 600 {
+		t.Errorf("%d of 1000 samples negative", neg)
+	}
+	wide := DiscreteUniformDist{Min: -1, Max: math.MaxInt}
+	for range 1000 {
+		if x := wide.RandWith(r); x < -1 {
+			t.Fatalf("sample %v below Min", x)
+		}
+	}
+}
+
+func TestHypergeometric_LargePopulationSampler(t *testing.T) {
+	d := HypergeometricDist{N: 10000, K: 5000, Draws: 5000}
+	xs := make([]float64, 50000)
+	FillWith(d, NewRand(23), xs)
+	var sum, sq float64
+	for _, x := range xs {
+		sum += x
+	}
+	mean := sum / float64(len(xs))
+	for _, x := range xs {
+		sq += (x - mean) * (x - mean)
+	}
+	variance := sq / float64(len(xs)-1)
+	if se := math.Sqrt(d.Variance() / float64(len(xs))); math.Abs(mean-d.Mean()) > 6*se {
+		t.Errorf("sample mean %v, want %v", mean, d.Mean())
+	}
+	if math.Abs(variance/d.Variance()-1) > 0.05 {
+		t.Errorf("sample variance %v, want %v", variance, d.Variance())
+	}
+}
+
+func TestNegBinomial_EntropyLargeScale(t *testing.T) {
+	for _, d := range []NegBinomialDist{{R: 3.5, P: 1e-3}, {R: 0.3, P: 1e-4}, {R: 50, P: 2e-3}} {
+		hi := int(d.Mean() + 60*d.StdDev() + 80/d.P)
+		want := discreteEntropy(func(k int) float64 { return d.PDF(float64(k)) }, 0, hi)
+		if got := d.Entropy(); math.Abs(got-want) > 1e-9 {
+			t.Errorf("%+v: entropy %v, want %v", d, got, want)
+		}
+	}
+	// A Gamma(R, θ) mixing density with a huge scale θ = (1-P)/P puts the
+	// entropy at R + ln θ + ln Γ(R) + (1-R)ψ(R).
+	d := NegBinomialDist{R: 3.5, P: 1e-300}
+	want := GammaDist{Shape: 3.5, Scale: 1e300}.Entropy()
+	if got := d.Entropy(); math.Abs(got-want) > 1e-9*want {
+		t.Errorf("P = 1e-300: entropy %v, want %v", got, want)
+	}
+}
+
+func TestZipf_CachedNormalizer(t *testing.T) {
+	z, err := NewZipf(1000, 1.1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lit := ZipfDist{N: 1000, S: 1.1}
+	for _, x := range []float64{1, 17, 1000} {
+		if z.PDF(x) != lit.PDF(x) || z.CDF(x) != lit.CDF(x) {
+			t.Errorf("at %v: cached PDF %v CDF %v, literal %v %v", x, z.PDF(x), z.CDF(x), lit.PDF(x), lit.CDF(x))
+		}
+	}
+	if got, err := ParseDist("zipf(1000, 1.1)"); err != nil || !reflect.DeepEqual(got, z) {
+		t.Errorf("ParseDist = %#v, %v; want the cached %#v", got, err, z)
+	}
+	// Changing a parameter after construction invalidates the cache.
+	z.N = 10
+	if got, want := z.PDF(1), (ZipfDist{N: 10, S: 1.1}).PDF(1); got != want {
+		t.Errorf("after changing N: PDF(1) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,161 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// DiscreteUniformDist is uniform over the integers Min, Min+1, ..., Max.
+type DiscreteUniformDist struct {
+	Min, Max int
+}
+
+// NewDiscreteUniform validates and returns
+// DiscreteUniformDist{Min: min, Max: max}.
+func NewDiscreteUniform(min, max int) (DiscreteUniformDist, error) {
+	d := DiscreteUniformDist{Min: min, Max: max}
+	return d, d.Validate()
+}
+
+func (d DiscreteUniformDist) Validate() error {
+	if d.Max < d.Min {
+		return paramError("DiscreteUniformDist", "Max", float64(d.Max), ">= Min")
+	}
+	return nil
+}
+
+func (d DiscreteUniformDist) n() float64 {
+	return float64(d.Max) - float64(d.Min) + 1
+}
+
+func (d DiscreteUniformDist) Rand() float64 {
+	return d.RandWith(nil)
+}
+
+func (d DiscreteUniformDist) RandWith(r *rand.Rand) float64 {
+	if d.Validate() != nil {
+		return math.NaN()
+	}
+	return float64(d.Min + int(d.offset(r)))
+}
+
+// offset returns a uniform integer in [0, Max-Min]. The width is computed in
+// uint64, where it cannot overflow even for Min = MinInt and Max = MaxInt.
+func (d DiscreteUniformDist) offset(r *rand.Rand) uint64 {
+	span := uint64(d.Max) - uint64(d.Min)
+	if span == math.MaxUint64 {
+		return uint64With(r)
+	}
+	return uint64N(r, span+1)
+}
+
+func (d DiscreteUniformDist) PDF(x float64) float64 {
+	if d.Validate() != nil {
+		return math.NaN()
+	}
+	if x != math.Round(x) || x < float64(d.Min) || x > float64(d.Max) {
+		return 0
+	}
+	return 1 / d.n()
+}
+
+func (d DiscreteUniformDist) CDF(x float64) float64 {
+	if d.Validate() != nil {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < float64(d.Min) {
+		return 0
+	}
+	if k >= float64(d.Max) {
+		return 1
+	}
+	return (k - float64(d.Min) + 1) / d.n()
+}
+
+func (d DiscreteUniformDist) Quantile(q float64) float64 {
+	if d.Validate() != nil || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	k := math.Max(math.Ceil(q*d.n())-1, 0)
+	return float64(d.Min) + k
+}
+
+func (d DiscreteUniformDist) Mean() float64 {
+	if d.Validate() != nil {
+		return math.NaN()
+	}
+	return 0.5 * (float64(d.Min) + float64(d.Max))
+}
+
+func (d DiscreteUniformDist) Variance() float64 {
+	if d.Validate() != nil {
+		return math.NaN()
+	}
+	n := d.n()
+	return (n*n - 1) / 12
+}
+
+func (d DiscreteUniformDist) StdDev() float64 {
+	return math.Sqrt(d.Variance())
+}
+
+func (d DiscreteUniformDist) Skewness() float64 {
+	if d.Max <= d.Min {
+		return math.NaN()
+	}
+	return 0
+}
+
+func (d DiscreteUniformDist) ExKurtosis() float64 {
+	if d.Max <= d.Min {
+		return math.NaN()
+	}
+	n2 := d.n() * d.n()
+	return -6 * (n2 + 1) / (5 * (n2 - 1))
+}
+
+// Mode returns Min; every point of the support is a mode.
+func (d DiscreteUniformDist) Mode() float64 {
+	if d.Validate() != nil {
+		return math.NaN()
+	}
+	return float64(d.Min)
+}
+
+func (d DiscreteUniformDist) Median() float64 {
+	return d.Quantile(0.5)
+}
+
+func (d DiscreteUniformDist) Entropy() float64 {
+	if d.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Log(d.n())
+}
+
+func (d DiscreteUniformDist) LogPDF(x float64) float64 {
+	return math.Log(d.PDF(x))
+}
+
+func (d DiscreteUniformDist) LogCDF(x float64) float64 {
+	return math.Log(d.CDF(x))
+}
+
+func (d DiscreteUniformDist) Survival(x float64) float64 {
+	if d.Validate() != nil {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < float64(d.Min) {
+		return 1
+	}
+	if k >= float64(d.Max) {
+		return 0
+	}
+	return (float64(d.Max) - k) / d.n()
+}
+
+func (d DiscreteUniformDist) LogSurvival(x float64) float64 {
+	return math.Log(d.Survival(x))
+}
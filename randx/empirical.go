@@ -0,0 +1,210 @@
+package randx
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sort"
+
+	"github.com/miguelm-revel/revelTools/randx/stats"
+)
+
+// BandwidthRule chooses the kernel bandwidth of an EmpiricalDist from its
+// sorted sample.
+type BandwidthRule func(sorted []float64) float64
+
+// Silverman is Silverman's rule of thumb, 0.9·min(σ, IQR/1.34)·n^(-1/5),
+// which stays robust for skewed or heavy-tailed samples. It falls back to σ
+// when the interquartile range is zero.
+func Silverman(sorted []float64) float64 {
+	sigma := sampleStdDev(sorted)
+	spread := (stats.QuantileSorted(sorted, 0.75) - stats.QuantileSorted(sorted, 0.25)) / 1.34
+	if spread > 0 && spread < sigma {
+		sigma = spread
+	}
+	return 0.9 * sigma * math.Pow(float64(len(sorted)), -0.2)
+}
+
+// Scott is Scott's rule, 1.06·σ·n^(-1/5), optimal for normal data.
+func Scott(sorted []float64) float64 {
+	return 1.06 * sampleStdDev(sorted) * math.Pow(float64(len(sorted)), -0.2)
+}
+
+// FixedBandwidth returns a rule that always chooses h.
+func FixedBandwidth(h float64) BandwidthRule {
+	return func([]float64) float64 { return h }
+}
+
+// EmpiricalDist is the distribution of an observed sample. Rand resamples the
+// observations uniformly with replacement (the bootstrap), CDF is the
+// empirical CDF, and PDF is a Gaussian kernel density estimate. It is
+// read-only after construction and safe for concurrent use.
+type EmpiricalDist struct {
+	sorted []float64
+	h      float64
+}
+
+// NewEmpirical builds the distribution of xs, which is copied. A nil rule
+// selects Silverman. It returns an error if xs is empty, holds a non-finite
+// value, or the rule yields a non-positive bandwidth, as it does for a
+// constant sample.
+func NewEmpirical(xs []float64, rule BandwidthRule) (*EmpiricalDist, error) {
+	if len(xs) == 0 {
+		return nil, paramError("EmpiricalDist", "xs", 0, "at least one observation")
+	}
+	for i, x := range xs {
+		if !finite(x) {
+			return nil, paramError("EmpiricalDist", fmt.Sprintf("xs[%d]", i), x, "finite")
+		}
+	}
+	sorted := slices.Clone(xs)
+	slices.Sort(sorted)
+	if rule == nil {
+		rule = Silverman
+	}
+	e := &EmpiricalDist{sorted: sorted, h: rule(sorted)}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Validate reports an EmpiricalDist that was not built by NewEmpirical or
+// whose bandwidth rule failed.
+func (e *EmpiricalDist) Validate() error {
+	if e == nil || len(e.sorted) == 0 {
+		return paramError("EmpiricalDist", "xs", 0, "at least one observation")
+	}
+	if !positive(e.h) {
+		return paramError("EmpiricalDist", "Bandwidth", e.h, "> 0")
+	}
+	return nil
+}
+
+// Len returns the number of observations, 0 for a nil EmpiricalDist.
+func (e *EmpiricalDist) Len() int {
+	if e == nil {
+		return 0
+	}
+	return len(e.sorted)
+}
+
+// Bandwidth returns the kernel bandwidth used by PDF, or NaN if e is invalid.
+func (e *EmpiricalDist) Bandwidth() float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	return e.h
+}
+
+func (e *EmpiricalDist) Rand() float64 {
+	return e.RandWith(nil)
+}
+
+func (e *EmpiricalDist) RandWith(r *rand.Rand) float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	return e.sorted[intN(r, len(e.sorted))]
+}
+
+func (e *EmpiricalDist) FillWith(r *rand.Rand, dst []float64) {
+	if e.Validate() != nil {
+		fillNaN(dst)
+		return
+	}
+	n := len(e.sorted)
+	for i := range dst {
+		dst[i] = e.sorted[intN(r, n)]
+	}
+}
+
+// PDF returns the Gaussian kernel density estimate at x. Observations more
+// than 8 bandwidths away contribute less than 1e-14 each and are skipped, so
+// the cost is proportional to the observations near x.
+func (e *EmpiricalDist) PDF(x float64) float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	lo, _ := slices.BinarySearch(e.sorted, x-8*e.h)
+	sum := 0.0
+	for _, xi := range e.sorted[lo:] {
+		z := (x - xi) / e.h
+		if z < -8 {
+			break
+		}
+		sum += math.Exp(-0.5 * z * z)
+	}
+	return sum / (float64(len(e.sorted)) * e.h * math.Sqrt(2*math.Pi))
+}
+
+// CDF returns the fraction of observations less than or equal to x.
+func (e *EmpiricalDist) CDF(x float64) float64 {
+	if e.Validate() != nil || math.IsNaN(x) {
+		return math.NaN()
+	}
+	k := sort.Search(len(e.sorted), func(i int) bool { return e.sorted[i] > x })
+	return float64(k) / float64(len(e.sorted))
+}
+
+// Quantile inverts the empirical CDF: it returns the smallest observation x
+// with CDF(x) >= q.
+func (e *EmpiricalDist) Quantile(q float64) float64 {
+	if e.Validate() != nil || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	k := int(math.Ceil(q*float64(len(e.sorted)))) - 1
+	return e.sorted[max(k, 0)]
+}
+
+// Mean returns the sample mean.
+func (e *EmpiricalDist) Mean() float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, x := range e.sorted {
+		sum += x
+	}
+	return sum / float64(len(e.sorted))
+}
+
+// Variance returns the variance of the empirical distribution, which divides
+// by n rather than n-1.
+func (e *EmpiricalDist) Variance() float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	mean := e.Mean()
+	sum := 0.0
+	for _, x := range e.sorted {
+		d := x - mean
+		sum += d * d
+	}
+	return sum / float64(len(e.sorted))
+}
+
+func (e *EmpiricalDist) StdDev() float64 {
+	return math.Sqrt(e.Variance())
+}
+
+// sampleStdDev returns the standard deviation of xs with the n-1 divisor, or
+// 0 for a single observation.
+func sampleStdDev(xs []float64) float64 {
+	n := float64(len(xs))
+	if n < 2 {
+		return 0
+	}
+	mean := 0.0
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= n
+	sum := 0.0
+	for _, x := range xs {
+		d := x - mean
+		sum += d * d
+	}
+	return math.Sqrt(sum / (n - 1))
+}
@@ -0,0 +1,121 @@
+package randx
+
+import (
+	"math"
+	"testing"
+)
+
+var _ interface {
+	Dist
+	Quantiler
+	Sampler
+} = (*EmpiricalDist)(nil)
+
+func TestEmpirical_CDFAndQuantile(t *testing.T) {
+	e, err := NewEmpirical([]float64{3, 1, 2, 2, 5}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cdf := map[float64]float64{0.5: 0, 1: 0.2, 1.5: 0.2, 2: 0.6, 4.9: 0.8, 5: 1, 7: 1}
+	for x, want := range cdf {
+		if got := e.CDF(x); got != want {
+			t.Errorf("CDF(%v) = %v, want %v", x, got, want)
+		}
+	}
+	quantile := map[float64]float64{0: 1, 0.2: 1, 0.21: 2, 0.6: 2, 0.8: 3, 1: 5}
+	for q, want := range quantile {
+		if got := e.Quantile(q); got != want {
+			t.Errorf("Quantile(%v) = %v, want %v", q, got, want)
+		}
+	}
+	if e.Mean() != 2.6 || math.Abs(e.Variance()-1.84) > 1e-12 {
+		t.Errorf("mean %v, variance %v, want 2.6, 1.84", e.Mean(), e.Variance())
+	}
+}
+
+func TestEmpirical_Bandwidth(t *testing.T) {
+	xs := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	// σ = 3.0277, IQR/1.34 = 4.5/1.34 = 3.358, so Silverman uses σ.
+	sigma := math.Sqrt(55.0 / 6)
+	scale := math.Pow(10, -0.2)
+	cases := []struct {
+		name string
+		rule BandwidthRule
+		want float64
+	}{
+		{"silverman", Silverman, 0.9 * sigma * scale},
+		{"scott", Scott, 1.06 * sigma * scale},
+		{"fixed", FixedBandwidth(0.25), 0.25},
+	}
+	for _, c := range cases {
+		e, err := NewEmpirical(xs, c.rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(e.Bandwidth()-c.want) > 1e-12 {
+			t.Errorf("%s bandwidth = %v, want %v", c.name, e.Bandwidth(), c.want)
+		}
+	}
+}
+
+// TestEmpirical_StandsInForExp builds an EmpiricalDist from exponential draws
+// and checks that its density integrates to 1 and tracks the source density,
+// and that resampling reproduces the source mean.
+func TestEmpirical_StandsInForExp(t *testing.T) {
+	src := ExpDist{Lambda: 2}
+	r := NewRand(3)
+	xs := make([]float64, 5000)
+	FillWith(src, r, xs)
+	e, err := NewEmpirical(xs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if area := simpson(e.PDF, -2, 10, 4000); math.Abs(area-1) > 1e-6 {
+		t.Errorf("KDE integrates to %v", area)
+	}
+	for _, x := range []float64{0.5, 1, 1.5} {
+		if got, want := e.PDF(x), src.PDF(x); math.Abs(got-want) > 0.1*want {
+			t.Errorf("PDF(%v) = %v, want ≈ %v", x, got, want)
+		}
+	}
+	var sum float64
+	const draws = 200000
+	for range draws {
+		sum += e.RandWith(r)
+	}
+	if got := sum / draws; math.Abs(got-e.Mean()) > 0.005 {
+		t.Errorf("bootstrap mean = %v, want ≈ %v", got, e.Mean())
+	}
+	resampled := make([]float64, 20000)
+	e.FillWith(r, resampled)
+	if stat := ksStatistic(resampled, src.CDF); stat > 0.02 {
+		t.Errorf("resampled KS distance to source = %v", stat)
+	}
+}
+
+func TestEmpirical_Invalid(t *testing.T) {
+	cases := map[string][]float64{
+		"empty":    nil,
+		"NaN":      {1, math.NaN()},
+		"constant": {2, 2, 2},
+	}
+	for name, xs := range cases {
+		if _, err := NewEmpirical(xs, nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if _, err := NewEmpirical([]float64{2, 2, 2}, FixedBandwidth(0.1)); err != nil {
+		t.Errorf("constant sample with fixed bandwidth: %v", err)
+	}
+	// A nil or zero EmpiricalDist reports NaN rather than panicking.
+	for name, e := range map[string]*EmpiricalDist{"nil": nil, "zero": {}} {
+		if e.Len() != 0 {
+			t.Errorf("%s: Len() = %d, want 0", name, e.Len())
+		}
+		for _, x := range []float64{e.Bandwidth(), e.Rand(), e.PDF(0), e.CDF(0), e.Quantile(0.5), e.Mean(), e.Variance(), e.StdDev()} {
+			if !math.IsNaN(x) {
+				t.Errorf("%s: got %v, want NaN", name, x)
+			}
+		}
+	}
+}
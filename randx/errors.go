@@ -0,0 +1,54 @@
+package randx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrInvalidParameter is wrapped by every error that Validate and the NewXxx
+// constructors return, so callers can test for it with errors.Is.
+var ErrInvalidParameter = errors.New("randx: invalid parameter")
+
+// ParamError reports the distribution parameter that failed validation.
+// Retrieve it with errors.As.
+type ParamError struct {
+	Dist  string  // the distribution type, e.g. "ExpDist"
+	Field string  // the offending field, e.g. "Lambda" or "Weights[2]"
+	Value float64 // the rejected value
+	Want  string  // the constraint it violates, e.g. "> 0"
+}
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("randx: %s: invalid %s = %v, want %s", e.Dist, e.Field, e.Value, e.Want)
+}
+
+func (e *ParamError) Unwrap() error {
+	return ErrInvalidParameter
+}
+
+// Validator is implemented by distributions that can check their parameters.
+// Validate returns nil or a *ParamError; the methods of an invalid
+// distribution return NaN.
+type Validator interface {
+	Validate() error
+}
+
+func paramError(dist, field string, v float64, want string) error {
+	return &ParamError{Dist: dist, Field: field, Value: v, Want: want}
+}
+
+// positive reports whether x is finite and strictly positive.
+func positive(x float64) bool {
+	return x > 0 && !math.IsInf(x, 1)
+}
+
+// finite reports whether x is neither NaN nor infinite.
+func finite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
+
+// probability reports whether p lies in [0, 1].
+func probability(p float64) bool {
+	return p >= 0 && p <= 1
+}
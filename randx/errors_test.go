@@ -0,0 +1,143 @@
+package randx
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestValidate_InvalidParameters(t *testing.T) {
+	nan := math.NaN()
+	cases := []struct {
+		d     Dist
+		field string
+	}{
+		{ExpDist{Lambda: 0}, "Lambda"},
+		{ExpDist{Lambda: -2}, "Lambda"},
+		{ExpDist{Lambda: nan}, "Lambda"},
+		{NormalDist{Mu: nan, Sigma: 1}, "Mu"},
+		{NormalDist{Mu: 0, Sigma: 0}, "Sigma"},
+		{Chi2Dist{K: -1}, "K"},
+		{GammaDist{Shape: 2, Scale: math.Inf(1)}, "Scale"},
+		{BetaDist{Alpha: 1, Beta: nan}, "Beta"},
+		{BinomDist{N: -1, P: 0.5}, "N"},
+		{BinomDist{N: 3, P: 1.5}, "P"},
+		{BernoulliDist{P: nan}, "P"},
+		{PoissonDist{Lambda: -1}, "Lambda"},
+		{UniformDist{Min: 1, Max: 1}, "Max"},
+		{DiscreteUniformDist{Min: 2, Max: 1}, "Max"},
+		{GeometricDist{P: 0}, "P"},
+		{NegBinomialDist{R: 2, P: 0}, "P"},
+		{HypergeometricDist{N: 5, K: 6, Draws: 1}, "K"},
+		{CategoricalDist{Weights: []float64{1, -1}}, "Weights[1]"},
+		{CategoricalDist{Weights: []float64{0, 0}}, "Weights"},
+		{ZipfDist{N: 0, S: 1}, "N"},
+		{ZipfDist{N: 5, S: nan}, "S"},
+		{ZipfDist{N: 5, S: math.Inf(1)}, "S"},
+		{FDist{D1: nan, D2: 20}, "D1"},
+		{FDist{D1: 3, D2: math.Inf(1)}, "D2"},
+		{StudentTDist{Nu: math.Inf(1)}, "Nu"},
+		{StudentTDist{Nu: nan}, "Nu"},
+		{ParetoDist{Xm: nan, Alpha: 5}, "Xm"},
+		{ParetoDist{Xm: 1, Alpha: math.Inf(1)}, "Alpha"},
+		{AffineDist{D: NormalDist{Mu: 0, Sigma: 1}, Loc: 0, Scale: -1}, "Scale"},
+		{AffineDist{D: ExpDist{Lambda: 0}, Loc: 0, Scale: 1}, "Lambda"},
+	}
+	for _, c := range cases {
+		err := c.d.(Validator).Validate()
+		var pe *ParamError
+		if !errors.Is(err, ErrInvalidParameter) || !errors.As(err, &pe) || pe.Field != c.field {
+			t.Errorf("%#v: Validate() = %v, want a ParamError for %s", c.d, err, c.field)
+			continue
+		}
+		// Every method agrees with Validate.
+		if x := c.d.Rand(); !math.IsNaN(x) {
+			t.Errorf("%#v: Rand() = %v, want NaN", c.d, x)
+		}
+		if x := c.d.PDF(0.5); !math.IsNaN(x) {
+			t.Errorf("%#v: PDF() = %v, want NaN", c.d, x)
+		}
+		if x := c.d.CDF(0.5); !math.IsNaN(x) {
+			t.Errorf("%#v: CDF() = %v, want NaN", c.d, x)
+		}
+		if m, ok := c.d.(Moments); ok {
+			for _, x := range []float64{m.Mean(), m.Variance(), m.Skewness(), m.ExKurtosis()} {
+				if !math.IsNaN(x) {
+					t.Errorf("%#v: moments %v, want NaN", c.d, []float64{m.Mean(), m.Variance(), m.Skewness(), m.ExKurtosis()})
+					break
+				}
+			}
+		}
+	}
+	bad := make([]float64, 4)
+	ExpDist{Lambda: -1}.FillWith(nil, bad)
+	for _, x := range bad {
+		if !math.IsNaN(x) {
+			t.Fatalf("FillWith with Lambda = -1 wrote %v", bad)
+		}
+	}
+}
+
+func TestValidate_Constructors(t *testing.T) {
+	if d, err := NewExp(0.5); err != nil || d != (ExpDist{Lambda: 0.5}) {
+		t.Errorf("NewExp(0.5) = %+v, %v", d, err)
+	}
+	if _, err := NewNormal(0, -1); err == nil {
+		t.Error("NewNormal(0, -1) succeeded")
+	}
+	_, err := NewChi2(-1)
+	want := "randx: Chi2Dist: invalid K = -1, want > 0"
+	if err == nil || err.Error() != want {
+		t.Errorf("NewChi2(-1) error = %v, want %q", err, want)
+	}
+	w := []float64{1, 2, 3}
+	c, err := NewCategorical(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w[0] = -1
+	if c.Weights[0] != 1 {
+		t.Error("NewCategorical did not copy the weights")
+	}
+	if _, err := NewDirichlet(nil); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("NewDirichlet(nil) error = %v", err)
+	}
+	if _, err := NewTruncated(ExpDist{Lambda: -1}, 0, 1); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("NewTruncated with an invalid inner distribution: error = %v", err)
+	}
+	if _, err := NewMixture([]Dist{nil}, []float64{1}); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("NewMixture with a nil component: error = %v", err)
+	}
+	if _, err := NewEmpirical([]float64{1, math.NaN()}, nil); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("NewEmpirical with NaN: error = %v", err)
+	}
+	if err := (&EmpiricalDist{}).Validate(); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("zero EmpiricalDist: error = %v", err)
+	}
+	if _, err := NewMultivariateNormal([]float64{0}, [][]float64{{-1}}); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("NewMultivariateNormal with a negative variance: error = %v", err)
+	}
+	emp, err := NewEmpirical([]float64{1, 2, 4}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid := []Validator{
+		BernoulliDist{P: 1}, BetaDist{Alpha: 0.5, Beta: 2}, BinomDist{N: 0, P: 0},
+		CauchyDist{X0: 1, Gamma: 1}, Chi2Dist{K: 3}, DiscreteUniformDist{Min: 3, Max: 3},
+		FDist{D1: 1, D2: 1}, GeometricDist{P: 1}, HypergeometricDist{N: 5, K: 5, Draws: 5},
+		LogNormalDist{Mu: 0, Sigma: 1}, MultinomialDist{N: 2, P: []float64{0, 1}},
+		NegBinomialDist{R: 0.5, P: 1}, ParetoDist{Xm: 1, Alpha: 3}, PoissonDist{Lambda: 0},
+		StudentTDist{Nu: 1}, UniformDist{Min: -1, Max: 1}, WeibullDist{K: 1, Lambda: 2},
+		ZipfDist{N: 1, S: 2}, DirichletDist{Alpha: []float64{1, 1}},
+		CensoredDist{D: NormalDist{Mu: 0, Sigma: 1}, Lo: math.Inf(-1), Hi: 0}, DiscretizedDist{D: ExpDist{Lambda: 1}},
+		TruncatedDist{D: PoissonDist{Lambda: 2}, Lo: 1, Hi: math.Inf(1)},
+		MixtureDist{Components: []Dist{ExpDist{Lambda: 1}, NormalDist{Mu: 0, Sigma: 1}}, Weights: []float64{2, 0}},
+		MultivariateNormalDist{Mu: []float64{0, 1}, Sigma: [][]float64{{1, 0.5}, {0.5, 1}}}, emp,
+	}
+	for _, v := range valid {
+		if err := v.Validate(); err != nil {
+			t.Errorf("%#v: unexpected error %v", v, err)
+		}
+	}
+}
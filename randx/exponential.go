@@ -9,17 +9,32 @@ type ExpDist struct {
 	Lambda float64
 }
 
-func (e ExpDist) Rand() float64 {
-	u := rand.Float64()
-	if u == 0 {
-		u = math.SmallestNonzeroFloat64
+// NewExp validates and returns ExpDist{Lambda: lambda}.
+func NewExp(lambda float64) (ExpDist, error) {
+	d := ExpDist{Lambda: lambda}
+	return d, d.Validate()
+}
+
+func (e ExpDist) Validate() error {
+	if !positive(e.Lambda) {
+		return paramError("ExpDist", "Lambda", e.Lambda, "> 0")
 	}
+	return nil
+}
+
+func (e ExpDist) Rand() float64 {
+	return e.RandWith(nil)
+}
 
-	return (-1.0 / e.Lambda) * math.Log(u)
+func (e ExpDist) RandWith(r *rand.Rand) float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	return stdExp(r) / e.Lambda
 }
 
 func (e ExpDist) PDF(x float64) float64 {
-	if e.Lambda <= 0 {
+	if e.Validate() != nil {
 		return math.NaN()
 	}
 	if x < 0 {
@@ -29,7 +44,7 @@ func (e ExpDist) PDF(x float64) float64 {
 }
 
 func (e ExpDist) CDF(x float64) float64 {
-	if e.Lambda <= 0 {
+	if e.Validate() != nil {
 		return math.NaN()
 	}
 	if x < 0 {
@@ -37,3 +52,124 @@ func (e ExpDist) CDF(x float64) float64 {
 	}
 	return 1 - math.Exp(-e.Lambda*x)
 }
+
+func (e ExpDist) Quantile(p float64) float64 {
+	if e.Validate() != nil || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return -math.Log1p(-p) / e.Lambda
+}
+
+func (e ExpDist) Mean() float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	return 1 / e.Lambda
+}
+
+func (e ExpDist) Variance() float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	return 1 / (e.Lambda * e.Lambda)
+}
+
+func (e ExpDist) StdDev() float64 {
+	return math.Sqrt(e.Variance())
+}
+
+func (e ExpDist) Skewness() float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	return 2
+}
+
+func (e ExpDist) ExKurtosis() float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	return 6
+}
+
+func (e ExpDist) Mode() float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	return 0
+}
+
+func (e ExpDist) Median() float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Ln2 / e.Lambda
+}
+
+func (e ExpDist) Entropy() float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	return 1 - math.Log(e.Lambda)
+}
+
+func (e ExpDist) Sample(n int) []float64 {
+	dst := make([]float64, n)
+	e.Fill(dst)
+	return dst
+}
+
+func (e ExpDist) Fill(dst []float64) {
+	e.FillWith(nil, dst)
+}
+
+func (e ExpDist) FillWith(r *rand.Rand, dst []float64) {
+	if e.Validate() != nil {
+		fillNaN(dst)
+		return
+	}
+	scale := 1.0 / e.Lambda
+	for i := range dst {
+		dst[i] = scale * stdExp(r)
+	}
+}
+
+func (e ExpDist) LogPDF(x float64) float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	return math.Log(e.Lambda) - e.Lambda*x
+}
+
+func (e ExpDist) LogCDF(x float64) float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return math.Inf(-1)
+	}
+	return math.Log(-math.Expm1(-e.Lambda * x))
+}
+
+func (e ExpDist) Survival(x float64) float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 1
+	}
+	return math.Exp(-e.Lambda * x)
+}
+
+func (e ExpDist) LogSurvival(x float64) float64 {
+	if e.Validate() != nil {
+		return math.NaN()
+	}
+	if x < 0 {
+		return 0
+	}
+	return -e.Lambda * x
+}
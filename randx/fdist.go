@@ -0,0 +1,192 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/miguelm-revel/revelTools/randx/special"
+)
+
+// FDist is Fisher–Snedecor's F distribution with D1 and D2 degrees of freedom.
+type FDist struct {
+	D1, D2 float64
+}
+
+// NewF validates and returns FDist{D1: d1, D2: d2}.
+func NewF(d1, d2 float64) (FDist, error) {
+	d := FDist{D1: d1, D2: d2}
+	return d, d.Validate()
+}
+
+func (f FDist) Validate() error {
+	if !positive(f.D1) {
+		return paramError("FDist", "D1", f.D1, "> 0")
+	}
+	if !positive(f.D2) {
+		return paramError("FDist", "D2", f.D2, "> 0")
+	}
+	return nil
+}
+
+func (f FDist) Rand() float64 {
+	return f.RandWith(nil)
+}
+
+func (f FDist) RandWith(r *rand.Rand) float64 {
+	if f.Validate() != nil {
+		return math.NaN()
+	}
+	x := gammaRand(r, f.D1/2.0) / f.D1
+	y := gammaRand(r, f.D2/2.0) / f.D2
+	return x / y
+}
+
+func (f FDist) PDF(x float64) float64 {
+	if f.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Exp(f.LogPDF(x))
+}
+
+func (f FDist) CDF(x float64) float64 {
+	if f.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 0
+	}
+	if math.IsInf(x, 1) {
+		return 1
+	}
+	return special.RegIncBeta(f.D1/2.0, f.D2/2.0, f.D1*x/(f.D1*x+f.D2))
+}
+
+func (f FDist) Quantile(p float64) float64 {
+	if f.Validate() != nil {
+		return math.NaN()
+	}
+	x := special.InvRegIncBeta(f.D1/2.0, f.D2/2.0, p)
+	if x == 1 {
+		return math.Inf(1)
+	}
+	return f.D2 * x / (f.D1 * (1 - x))
+}
+
+// Mean is D2/(D2-2) for D2 > 2 and undefined (NaN) otherwise.
+func (f FDist) Mean() float64 {
+	if f.Validate() != nil || f.D2 <= 2 {
+		return math.NaN()
+	}
+	return f.D2 / (f.D2 - 2)
+}
+
+func (f FDist) Variance() float64 {
+	if f.Validate() != nil || f.D2 <= 4 {
+		return math.NaN()
+	}
+	d := f.D2 - 2
+	return 2 * f.D2 * f.D2 * (f.D1 + f.D2 - 2) / (f.D1 * d * d * (f.D2 - 4))
+}
+
+func (f FDist) StdDev() float64 {
+	return math.Sqrt(f.Variance())
+}
+
+func (f FDist) Skewness() float64 {
+	if f.Validate() != nil || f.D2 <= 6 {
+		return math.NaN()
+	}
+	return (2*f.D1 + f.D2 - 2) * math.Sqrt(8*(f.D2-4)) / ((f.D2 - 6) * math.Sqrt(f.D1*(f.D1+f.D2-2)))
+}
+
+func (f FDist) ExKurtosis() float64 {
+	if f.Validate() != nil || f.D2 <= 8 {
+		return math.NaN()
+	}
+	d := f.D2 - 2
+	num := f.D1*(5*f.D2-22)*(f.D1+f.D2-2) + (f.D2-4)*d*d
+	return 12 * num / (f.D1 * (f.D2 - 6) * (f.D2 - 8) * (f.D1 + f.D2 - 2))
+}
+
+func (f FDist) Mode() float64 {
+	if f.Validate() != nil {
+		return math.NaN()
+	}
+	if f.D1 <= 2 {
+		return 0
+	}
+	return (f.D1 - 2) / f.D1 * f.D2 / (f.D2 + 2)
+}
+
+func (f FDist) Median() float64 {
+	return f.Quantile(0.5)
+}
+
+func (f FDist) Entropy() float64 {
+	if f.Validate() != nil {
+		return math.NaN()
+	}
+	a, b := f.D1/2.0, f.D2/2.0
+	return special.LogBeta(a, b) + (1-a)*special.Digamma(a) - (1+b)*special.Digamma(b) + (a+b)*special.Digamma(a+b) + math.Log(f.D2/f.D1)
+}
+
+func (f FDist) LogPDF(x float64) float64 {
+	if f.Validate() != nil {
+		return math.NaN()
+	}
+	if x < 0 || math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	if x == 0 {
+		switch {
+		case f.D1 < 2:
+			return math.Inf(1)
+		case f.D1 == 2:
+			return 0
+		default:
+			return math.Inf(-1)
+		}
+	}
+	a, b := f.D1/2.0, f.D2/2.0
+	return a*math.Log(f.D1/f.D2) + (a-1)*math.Log(x) - (a+b)*math.Log1p(f.D1*x/f.D2) - special.LogBeta(a, b)
+}
+
+func (f FDist) LogCDF(x float64) float64 {
+	if f.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return math.Inf(-1)
+	}
+	if math.IsInf(x, 1) {
+		return 0
+	}
+	return special.LogRegIncBeta(f.D1/2.0, f.D2/2.0, f.D1*x/(f.D1*x+f.D2))
+}
+
+// Survival returns I_{d2/(d1·x+d2)}(d2/2, d1/2), which equals 1 - CDF(x).
+func (f FDist) Survival(x float64) float64 {
+	if f.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 1
+	}
+	if math.IsInf(x, 1) {
+		return 0
+	}
+	return special.RegIncBeta(f.D2/2.0, f.D1/2.0, f.D2/(f.D1*x+f.D2))
+}
+
+func (f FDist) LogSurvival(x float64) float64 {
+	if f.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= 0 {
+		return 0
+	}
+	if math.IsInf(x, 1) {
+		return math.Inf(-1)
+	}
+	return special.LogRegIncBeta(f.D2/2.0, f.D1/2.0, f.D2/(f.D1*x+f.D2))
+}
@@ -0,0 +1,142 @@
+// Package fit estimates the parameters of randx distributions from observed
+// samples by maximum likelihood.
+package fit
+
+import (
+	"errors"
+	"math"
+
+	"github.com/miguelm-revel/revelTools/randx"
+)
+
+// maxIter bounds the Newton iterations of the estimators without a closed
+// form.
+const maxIter = 100
+
+// Result is a distribution fitted to a sample. LogLik is the log-likelihood
+// of the sample at the estimate, and StdErr holds the asymptotic standard
+// errors of the parameters, derived from the Fisher information, in the order
+// they are declared in D.
+type Result[D randx.Dist] struct {
+	Dist   D
+	LogLik float64
+	StdErr []float64
+}
+
+// logLik sums the log-density of d over xs.
+func logLik(d randx.Dist, xs []float64) float64 {
+	sum := 0.0
+	for _, x := range xs {
+		sum += randx.LogPDF(d, x)
+	}
+	return sum
+}
+
+// moments returns the sample mean and the biased (1/n) variance of xs, or an
+// error if xs is empty or holds a non-finite value.
+func moments(xs []float64) (mean, variance float64, err error) {
+	if len(xs) == 0 {
+		return 0, 0, errors.New("fit: empty sample")
+	}
+	for _, x := range xs {
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return 0, 0, errors.New("fit: sample contains NaN or Inf")
+		}
+		mean += x
+	}
+	n := float64(len(xs))
+	mean /= n
+	for _, x := range xs {
+		d := x - mean
+		variance += d * d
+	}
+	return mean, variance / n, nil
+}
+
+// FitNormal estimates μ by the sample mean and σ by the root of the biased
+// sample variance.
+func FitNormal(xs []float64) (Result[randx.NormalDist], error) {
+	mean, variance, err := moments(xs)
+	if err != nil {
+		return Result[randx.NormalDist]{}, err
+	}
+	if variance == 0 {
+		return Result[randx.NormalDist]{}, errors.New("fit: sample has zero variance")
+	}
+	n := float64(len(xs))
+	sigma := math.Sqrt(variance)
+	return Result[randx.NormalDist]{
+		Dist:   randx.NormalDist{Mu: mean, Sigma: sigma},
+		LogLik: -0.5 * n * (math.Log(2*math.Pi*variance) + 1),
+		StdErr: []float64{sigma / math.Sqrt(n), sigma / math.Sqrt(2*n)},
+	}, nil
+}
+
+// FitExp estimates λ as the reciprocal of the sample mean. The sample must
+// be non-negative and not all zero.
+func FitExp(xs []float64) (Result[randx.ExpDist], error) {
+	mean, _, err := moments(xs)
+	if err != nil {
+		return Result[randx.ExpDist]{}, err
+	}
+	for _, x := range xs {
+		if x < 0 {
+			return Result[randx.ExpDist]{}, errors.New("fit: exponential sample must be non-negative")
+		}
+	}
+	if mean == 0 {
+		return Result[randx.ExpDist]{}, errors.New("fit: exponential sample is all zero")
+	}
+	n := float64(len(xs))
+	lambda := 1 / mean
+	return Result[randx.ExpDist]{
+		Dist:   randx.ExpDist{Lambda: lambda},
+		LogLik: n * (math.Log(lambda) - 1),
+		StdErr: []float64{lambda / math.Sqrt(n)},
+	}, nil
+}
+
+// FitPoisson estimates λ by the sample mean. The sample must hold
+// non-negative integers.
+func FitPoisson(xs []float64) (Result[randx.PoissonDist], error) {
+	mean, _, err := moments(xs)
+	if err != nil {
+		return Result[randx.PoissonDist]{}, err
+	}
+	for _, x := range xs {
+		if x < 0 || x != math.Floor(x) {
+			return Result[randx.PoissonDist]{}, errors.New("fit: Poisson sample must hold non-negative integers")
+		}
+	}
+	d := randx.PoissonDist{Lambda: mean}
+	return Result[randx.PoissonDist]{
+		Dist:   d,
+		LogLik: logLik(d, xs),
+		StdErr: []float64{math.Sqrt(mean / float64(len(xs)))},
+	}, nil
+}
+
+// FitBinom estimates P for a known number of trials n as the sample mean
+// divided by n. The sample must hold integers in [0, n]. StdErr has a single
+// entry, for P.
+func FitBinom(xs []float64, n int) (Result[randx.BinomDist], error) {
+	if n < 1 {
+		return Result[randx.BinomDist]{}, errors.New("fit: binomial trials must be positive")
+	}
+	mean, _, err := moments(xs)
+	if err != nil {
+		return Result[randx.BinomDist]{}, err
+	}
+	for _, x := range xs {
+		if x < 0 || x > float64(n) || x != math.Floor(x) {
+			return Result[randx.BinomDist]{}, errors.New("fit: binomial sample must hold integers in [0, n]")
+		}
+	}
+	p := mean / float64(n)
+	d := randx.BinomDist{N: n, P: p}
+	return Result[randx.BinomDist]{
+		Dist:   d,
+		LogLik: logLik(d, xs),
+		StdErr: []float64{math.Sqrt(p * (1 - p) / (float64(n) * float64(len(xs))))},
+	}, nil
+}
@@ -0,0 +1,131 @@
+package fit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/miguelm-revel/revelTools/randx"
+)
+
+func sample(d randx.Dist, n int, seed uint64) []float64 {
+	r := randx.NewRand(seed)
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = d.RandWith(r)
+	}
+	return xs
+}
+
+// within reports whether got is within z standard errors of want.
+func within(got, want, se, z float64) bool {
+	return math.Abs(got-want) <= z*se
+}
+
+func TestFit_RecoversParameters(t *testing.T) {
+	const n = 20000
+	check := func(name string, got, want, se float64) {
+		t.Helper()
+		if se <= 0 || !within(got, want, se, 5) {
+			t.Errorf("%s: estimate %v (se %v), want %v", name, got, se, want)
+		}
+	}
+
+	normal, err := FitNormal(sample(randx.NormalDist{Mu: -2, Sigma: 3}, n, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("normal μ", normal.Dist.Mu, -2, normal.StdErr[0])
+	check("normal σ", normal.Dist.Sigma, 3, normal.StdErr[1])
+
+	exp, err := FitExp(sample(randx.ExpDist{Lambda: 0.7}, n, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("exp λ", exp.Dist.Lambda, 0.7, exp.StdErr[0])
+
+	pois, err := FitPoisson(sample(randx.PoissonDist{Lambda: 6.5}, n, 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("poisson λ", pois.Dist.Lambda, 6.5, pois.StdErr[0])
+
+	binom, err := FitBinom(sample(randx.BinomDist{N: 25, P: 0.3}, n, 4), 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("binom p", binom.Dist.P, 0.3, binom.StdErr[0])
+
+	for _, shape := range []float64{0.3, 2.5, 40} {
+		g, err := FitGamma(sample(randx.GammaDist{Shape: shape, Scale: 1.7}, n, 5))
+		if err != nil {
+			t.Fatal(err)
+		}
+		check("gamma shape", g.Dist.Shape, shape, g.StdErr[0])
+		check("gamma scale", g.Dist.Scale, 1.7, g.StdErr[1])
+	}
+
+	chi2, err := FitChi2(sample(randx.Chi2Dist{K: 7}, n, 6))
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("chi2 k", chi2.Dist.K, 7, chi2.StdErr[0])
+}
+
+// TestFit_LogLikIsMaximal checks that the reported log-likelihood matches the
+// sum of log-densities and that perturbing the estimate lowers it.
+func TestFit_LogLikIsMaximal(t *testing.T) {
+	xs := sample(randx.GammaDist{Shape: 3, Scale: 0.5}, 500, 7)
+
+	normal, _ := FitNormal(xs)
+	g, _ := FitGamma(xs)
+	exp, _ := FitExp(xs)
+	chi2, _ := FitChi2(xs)
+	cases := []struct {
+		name   string
+		logLik float64
+		at     func(eps float64) randx.Dist
+	}{
+		{"normal", normal.LogLik, func(e float64) randx.Dist {
+			return randx.NormalDist{Mu: normal.Dist.Mu + e, Sigma: normal.Dist.Sigma * (1 + e)}
+		}},
+		{"gamma", g.LogLik, func(e float64) randx.Dist {
+			return randx.GammaDist{Shape: g.Dist.Shape * (1 + e), Scale: g.Dist.Scale}
+		}},
+		{"exp", exp.LogLik, func(e float64) randx.Dist {
+			return randx.ExpDist{Lambda: exp.Dist.Lambda * (1 + e)}
+		}},
+		{"chi2", chi2.LogLik, func(e float64) randx.Dist {
+			return randx.Chi2Dist{K: chi2.Dist.K * (1 + e)}
+		}},
+	}
+	for _, c := range cases {
+		if got := logLik(c.at(0), xs); math.Abs(got-c.logLik) > 1e-8*math.Abs(got) {
+			t.Errorf("%s: LogLik = %v, sum of log-densities = %v", c.name, c.logLik, got)
+		}
+		for _, eps := range []float64{-1e-3, 1e-3} {
+			if got := logLik(c.at(eps), xs); got >= c.logLik {
+				t.Errorf("%s: log-likelihood %v at ε=%v is not below the maximum %v", c.name, got, eps, c.logLik)
+			}
+		}
+	}
+}
+
+func TestFit_RejectsInvalidSamples(t *testing.T) {
+	errs := map[string]error{}
+	_, errs["normal empty"] = FitNormal(nil)
+	_, errs["normal constant"] = FitNormal([]float64{2, 2, 2})
+	_, errs["normal NaN"] = FitNormal([]float64{1, math.NaN()})
+	_, errs["exp negative"] = FitExp([]float64{1, -1})
+	_, errs["exp zero"] = FitExp([]float64{0, 0})
+	_, errs["poisson fractional"] = FitPoisson([]float64{1, 2.5})
+	_, errs["binom out of range"] = FitBinom([]float64{1, 6}, 5)
+	_, errs["binom no trials"] = FitBinom([]float64{0}, 0)
+	_, errs["gamma zero"] = FitGamma([]float64{1, 0})
+	_, errs["gamma constant"] = FitGamma([]float64{3, 3})
+	_, errs["chi2 negative"] = FitChi2([]float64{-1})
+	for name, err := range errs {
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package fit
+
+import (
+	"errors"
+	"math"
+
+	"github.com/miguelm-revel/revelTools/randx"
+	"github.com/miguelm-revel/revelTools/randx/special"
+)
+
+// logMoments returns the mean of xs and the mean of their logarithms, or an
+// error unless every value is finite and strictly positive.
+func logMoments(xs []float64) (mean, meanLog float64, err error) {
+	mean, _, err = moments(xs)
+	if err != nil {
+		return 0, 0, err
+	}
+	for _, x := range xs {
+		if x <= 0 {
+			return 0, 0, errors.New("fit: sample must be strictly positive")
+		}
+		meanLog += math.Log(x)
+	}
+	return mean, meanLog / float64(len(xs)), nil
+}
+
+// FitGamma estimates shape and scale. The shape k solves
+// ln k - ψ(k) = ln(mean) - mean(ln x) by Newton iteration from Minka's
+// closed-form approximation, and the scale is mean / k. The sample must be
+// strictly positive and not constant.
+func FitGamma(xs []float64) (Result[randx.GammaDist], error) {
+	mean, meanLog, err := logMoments(xs)
+	if err != nil {
+		return Result[randx.GammaDist]{}, err
+	}
+	s := math.Log(mean) - meanLog
+	if s <= 0 {
+		return Result[randx.GammaDist]{}, errors.New("fit: sample has zero variance")
+	}
+
+	k := (3 - s + math.Sqrt((s-3)*(s-3)+24*s)) / (12 * s)
+	for range maxIter {
+		step := (math.Log(k) - special.Digamma(k) - s) / (1/k - special.Trigamma(k))
+		// Newton can overshoot below zero for very small shapes.
+		if k-step <= 0 {
+			k /= 2
+			continue
+		}
+		k -= step
+		if math.Abs(step) <= 1e-14*k {
+			break
+		}
+	}
+
+	theta := mean / k
+	d := randx.GammaDist{Shape: k, Scale: theta}
+	n := float64(len(xs))
+	// The Fisher information of (k, θ) is n·[[ψ'(k), 1/θ], [1/θ, k/θ²]].
+	tg := special.Trigamma(k)
+	det := n * (k*tg - 1)
+	return Result[randx.GammaDist]{
+		Dist:   d,
+		LogLik: logLik(d, xs),
+		StdErr: []float64{math.Sqrt(k / det), theta * math.Sqrt(tg/det)},
+	}, nil
+}
+
+// FitChi2 estimates the degrees of freedom k, which satisfy
+// ψ(k/2) = mean(ln x) - ln 2. The sample must be strictly positive.
+func FitChi2(xs []float64) (Result[randx.Chi2Dist], error) {
+	_, meanLog, err := logMoments(xs)
+	if err != nil {
+		return Result[randx.Chi2Dist]{}, err
+	}
+	half := special.InvDigamma(meanLog - math.Ln2)
+	d := randx.Chi2Dist{K: 2 * half}
+	return Result[randx.Chi2Dist]{
+		Dist:   d,
+		LogLik: logLik(d, xs),
+		StdErr: []float64{2 / math.Sqrt(float64(len(xs))*special.Trigamma(half))},
+	}, nil
+}
@@ -0,0 +1,183 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// GeometricDist counts the failures before the first success of independent
+// trials with success probability P, so its support is {0, 1, 2, ...}.
+type GeometricDist struct {
+	P float64
+}
+
+// NewGeometric validates and returns GeometricDist{P: p}.
+func NewGeometric(p float64) (GeometricDist, error) {
+	d := GeometricDist{P: p}
+	return d, d.Validate()
+}
+
+func (g GeometricDist) Validate() error {
+	if !(g.P > 0 && g.P <= 1) {
+		return paramError("GeometricDist", "P", g.P, "in (0, 1]")
+	}
+	return nil
+}
+
+func (g GeometricDist) Rand() float64 {
+	return g.RandWith(nil)
+}
+
+func (g GeometricDist) RandWith(r *rand.Rand) float64 {
+	if g.Validate() != nil {
+		return math.NaN()
+	}
+	if g.P == 1 {
+		return 0
+	}
+	return math.Floor(stdExp(r) / -math.Log1p(-g.P))
+}
+
+func (g GeometricDist) PDF(x float64) float64 {
+	if g.Validate() != nil {
+		return math.NaN()
+	}
+	k := int(math.Round(x))
+	if float64(k) != x || k < 0 {
+		return 0
+	}
+	if g.P == 1 {
+		if k == 0 {
+			return 1
+		}
+		return 0
+	}
+	return g.P * math.Exp(float64(k)*math.Log1p(-g.P))
+}
+
+func (g GeometricDist) CDF(x float64) float64 {
+	if g.Validate() != nil {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 0 {
+		return 0
+	}
+	if g.P == 1 {
+		return 1
+	}
+	return -math.Expm1((k + 1) * math.Log1p(-g.P))
+}
+
+func (g GeometricDist) Quantile(q float64) float64 {
+	if g.Validate() != nil || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	if q == 0 || g.P == 1 {
+		return 0
+	}
+	if q == 1 {
+		return math.Inf(1)
+	}
+	guess := math.Ceil(math.Log1p(-q)/math.Log1p(-g.P)) - 1
+	if guess > math.MaxInt32 {
+		return guess
+	}
+	cdf := func(k int) float64 { return g.CDF(float64(k)) }
+	return float64(discreteQuantile(cdf, q, int(guess), 0, math.MaxInt))
+}
+
+func (g GeometricDist) Mean() float64 {
+	if g.Validate() != nil {
+		return math.NaN()
+	}
+	return (1 - g.P) / g.P
+}
+
+func (g GeometricDist) Variance() float64 {
+	if g.Validate() != nil {
+		return math.NaN()
+	}
+	return (1 - g.P) / (g.P * g.P)
+}
+
+func (g GeometricDist) StdDev() float64 {
+	return math.Sqrt(g.Variance())
+}
+
+// Skewness is NaN when P is 1, where the distribution is a point mass.
+func (g GeometricDist) Skewness() float64 {
+	if g.Validate() != nil || g.Variance() == 0 {
+		return math.NaN()
+	}
+	return (2 - g.P) / math.Sqrt(1-g.P)
+}
+
+func (g GeometricDist) ExKurtosis() float64 {
+	if g.Validate() != nil || g.Variance() == 0 {
+		return math.NaN()
+	}
+	return 6 + g.P*g.P/(1-g.P)
+}
+
+func (g GeometricDist) Mode() float64 {
+	if g.Validate() != nil {
+		return math.NaN()
+	}
+	return 0
+}
+
+func (g GeometricDist) Median() float64 {
+	return g.Quantile(0.5)
+}
+
+func (g GeometricDist) Entropy() float64 {
+	if g.Validate() != nil {
+		return math.NaN()
+	}
+	if g.P == 1 {
+		return 0
+	}
+	q := 1 - g.P
+	return -(q*math.Log(q) + g.P*math.Log(g.P)) / g.P
+}
+
+func (g GeometricDist) LogPDF(x float64) float64 {
+	if g.Validate() != nil {
+		return math.NaN()
+	}
+	k := int(math.Round(x))
+	if float64(k) != x || k < 0 {
+		return math.Inf(-1)
+	}
+	if g.P == 1 {
+		if k == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	return math.Log(g.P) + float64(k)*math.Log1p(-g.P)
+}
+
+func (g GeometricDist) LogCDF(x float64) float64 {
+	return math.Log(g.CDF(x))
+}
+
+func (g GeometricDist) Survival(x float64) float64 {
+	return math.Exp(g.LogSurvival(x))
+}
+
+// LogSurvival returns (k+1)·log(1-p), exact for every k.
+func (g GeometricDist) LogSurvival(x float64) float64 {
+	if g.Validate() != nil {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 0 {
+		return 0
+	}
+	if g.P == 1 {
+		return math.Inf(-1)
+	}
+	return (k + 1) * math.Log1p(-g.P)
+}
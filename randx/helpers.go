@@ -3,171 +3,439 @@ package randx
 import (
 	"math"
 	"math/rand/v2"
+
+	"github.com/miguelm-revel/revelTools/randx/special"
 )
 
 /* =============================
-   Helpers: combinatoria, gamma, etc.
+   Helpers: muestreadores y búsquedas.
 =============================*/
 
-func logGamma(x float64) float64 {
-	lg, _ := math.Lgamma(x)
-	return lg
-}
-
-func logFactorial(n int) float64 {
-	return logGamma(float64(n) + 1.0)
-}
-
-func logChoose(n, k int) float64 {
-	if k < 0 || k > n {
-		return math.Inf(-1)
-	}
-	return logFactorial(n) - logFactorial(k) - logFactorial(n-k)
-}
-
 /* -----------------------------
    Gamma RNG: Marsaglia–Tsang
    Devuelve Gamma(shape, scale=1)
 ------------------------------*/
 
-func gammaRand(shape float64) float64 {
+func gammaRand(r *rand.Rand, shape float64) float64 {
 	if shape <= 0 {
 		return math.NaN()
 	}
+	return newGammaSampler(shape).sample(r)
+}
+
+// gammaSampler guarda las constantes de Marsaglia–Tsang para reutilizarlas
+// entre muestras con la misma forma.
+type gammaSampler struct {
+	d, c float64
+	// invShape > 0 indica shape < 1: se muestrea Gamma(shape+1) y se
+	// multiplica por U^(1/shape) = exp(-E/shape), con E ~ Exp(1).
+	invShape float64
+}
 
+func newGammaSampler(shape float64) gammaSampler {
+	g := gammaSampler{}
 	if shape < 1.0 {
-		u := rand.Float64()
-		if u == 0 {
-			u = math.SmallestNonzeroFloat64
+		g.invShape = 1.0 / shape
+		shape += 1.0
+	}
+	g.d = shape - 1.0/3.0
+	g.c = 1.0 / math.Sqrt(9.0*g.d)
+	return g
+}
+
+func (g gammaSampler) sample(r *rand.Rand) float64 {
+	for {
+		x := stdNormal(r)
+		v := 1.0 + g.c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := uniform(r)
+		if u < 1.0-0.0331*(x*x)*(x*x) || math.Log(u) < 0.5*x*x+g.d*(1.0-v+math.Log(v)) {
+			if g.invShape > 0 {
+				return g.d * v * math.Exp(-stdExp(r)*g.invShape)
+			}
+			return g.d * v
 		}
-		return gammaRand(shape+1.0) * math.Pow(u, 1.0/shape)
 	}
+}
+
+// logSample devuelve el logaritmo de una muestra. Con shape < 1 evita el
+// underflow de U^(1/shape): ln G = ln G(shape+1) + ln U / shape.
+func (g gammaSampler) logSample(r *rand.Rand) float64 {
+	if g.invShape == 0 {
+		return math.Log(g.sample(r))
+	}
+	inner := gammaSampler{d: g.d, c: g.c}
+	return math.Log(inner.sample(r)) - stdExp(r)*g.invShape
+}
+
+func logGammaRand(r *rand.Rand, shape float64) float64 {
+	return newGammaSampler(shape).logSample(r)
+}
+
+/* -----------------------------
+   Poisson PTRS (Hörmann, 1993)
+------------------------------*/
 
-	d := shape - 1.0/3.0
-	c := 1.0 / math.Sqrt(9.0*d)
+func poissonPTRS(r *rand.Rand, lambda float64) int {
+	return newPTRS(lambda).sample(r)
+}
+
+// ptrs contiene las constantes de PTRS, que sólo dependen de lambda.
+type ptrs struct {
+	lambda, logL       float64
+	a, b, invAlpha, vR float64
+}
 
-	nd := NormalDist{Mu: 0, Sigma: 1}
+func newPTRS(lambda float64) ptrs {
+	sqrtL := math.Sqrt(lambda)
+	b := 0.931 + 2.53*sqrtL
+	return ptrs{
+		lambda:   lambda,
+		logL:     math.Log(lambda),
+		a:        -0.059 + 0.02483*b,
+		b:        b,
+		invAlpha: 1.1239 + 1.1328/(b-3.4),
+		vR:       0.9277 - 3.6224/(b-2.0),
+	}
+}
 
+func (p ptrs) sample(r *rand.Rand) int {
 	for {
-		x := nd.Rand()
-		v := 1.0 + c*x
-		if v <= 0 {
+		u := uniform(r) - 0.5
+		v := uniform(r)
+
+		us := 0.5 - math.Abs(u)
+		k := int(math.Floor((2*p.a/us+p.b)*u + p.lambda + 0.43))
+		if k < 0 {
 			continue
 		}
-		v = v * v * v
-		u := rand.Float64()
-		if u < 1.0-0.0331*(x*x)*(x*x) {
-			return d * v
+
+		if us >= 0.07 && v <= p.vR {
+			return k
 		}
-		if math.Log(u) < 0.5*x*x+d*(1.0-v+math.Log(v)) {
-			return d * v
+
+		lhs := math.Log(v * p.invAlpha / (p.a/(us*us) + p.b))
+		rhs := float64(k)*p.logL - p.lambda - special.LogFactorial(k)
+		if lhs <= rhs {
+			return k
 		}
 	}
 }
 
 /* -----------------------------
-   Regularized lower incomplete gamma:
-   P(a,x) = γ(a,x)/Γ(a)
-   Usada para CDF de Chi² y Poisson.
+   Cuantiles discretos y entropía.
+   Las funciones especiales viven en randx/special.
 ------------------------------*/
 
-func regLowerGamma(a, x float64) float64 {
-	if a <= 0 || x < 0 {
-		return math.NaN()
+// discreteQuantile busca el menor k en [lo, hi] con cdf(k) >= p,
+// partiendo de guess (típicamente una aproximación normal).
+func discreteQuantile(cdf func(k int) float64, p float64, guess, lo, hi int) int {
+	k := min(max(guess, lo), hi)
+	if cdf(k) >= p {
+		for k > lo && cdf(k-1) >= p {
+			k--
+		}
+		return k
 	}
-	if x == 0 {
-		return 0
+	for k < hi && cdf(k) < p {
+		k++
 	}
+	return k
+}
 
-	if x < a+1.0 {
-		return gammaSeries(a, x)
+// discreteEntropy suma -p·ln p sobre [lo, hi], ignorando términos nulos.
+func discreteEntropy(pmf func(k int) float64, lo, hi int) float64 {
+	h := 0.0
+	for k := lo; k <= hi; k++ {
+		if p := pmf(k); p > 0 {
+			h -= p * math.Log(p)
+		}
 	}
-	return 1.0 - gammaContFrac(a, x)
+	return h
 }
 
-func gammaSeries(a, x float64) float64 {
-	const itmax = 200
-	const eps = 3e-14
+/* -----------------------------
+   Binomial RNG: inversión para n·p < 30 y
+   BTPE (Kachitvichyanukul & Schmeiser, 1988) en otro caso.
+------------------------------*/
 
-	sum := 1.0 / a
-	del := sum
-	ap := a
+// binomSampler guarda las constantes de inversión o BTPE para (n, p).
+// Muestrea siempre con pp = min(p, 1-p) y refleja el resultado si p > 0.5.
+type binomSampler struct {
+	n     int
+	pp, q float64
+	flip  bool
+	btpe  bool
 
-	for n := 1; n <= itmax; n++ {
-		ap += 1.0
-		del *= x / ap
-		sum += del
-		if math.Abs(del) < math.Abs(sum)*eps {
-			break
-		}
+	// Inversión
+	qn    float64
+	bound int
+
+	// BTPE
+	m                      int
+	nrq, p1, p2, p3, p4    float64
+	xm, xl, xr, c          float64
+	laml, lamr, logRatioPQ float64
+}
+
+func newBinomSampler(n int, p float64) binomSampler {
+	s := binomSampler{n: n, pp: p, flip: p > 0.5}
+	if s.flip {
+		s.pp = 1 - p
+	}
+	s.q = 1 - s.pp
+	nf := float64(n)
+	np := nf * s.pp
+
+	if np < 30 {
+		s.qn = math.Exp(nf * math.Log1p(-s.pp))
+		s.bound = int(math.Min(nf, np+10*math.Sqrt(np*s.q+1)))
+		return s
 	}
 
-	return sum * math.Exp(-x+a*math.Log(x)-logGamma(a))
+	s.btpe = true
+	fm := np + s.pp
+	s.m = int(math.Floor(fm))
+	s.nrq = np * s.q
+	s.p1 = math.Floor(2.195*math.Sqrt(s.nrq)-4.6*s.q) + 0.5
+	s.xm = float64(s.m) + 0.5
+	s.xl = s.xm - s.p1
+	s.xr = s.xm + s.p1
+	s.c = 0.134 + 20.5/(15.3+float64(s.m))
+	a := (fm - s.xl) / (fm - s.xl*s.pp)
+	s.laml = a * (1 + a/2)
+	a = (s.xr - fm) / (s.xr * s.q)
+	s.lamr = a * (1 + a/2)
+	s.p2 = s.p1 * (1 + 2*s.c)
+	s.p3 = s.p2 + s.c/s.laml
+	s.p4 = s.p3 + s.c/s.lamr
+	s.logRatioPQ = math.Log(s.pp / s.q)
+	return s
 }
 
-func gammaContFrac(a, x float64) float64 {
-	const itmax = 200
-	const eps = 3e-14
-	const fpmin = 1e-300
+func (s binomSampler) sample(r *rand.Rand) int {
+	var k int
+	if s.btpe {
+		k = s.sampleBTPE(r)
+	} else {
+		k = s.sampleInversion(r)
+	}
+	if s.flip {
+		return s.n - k
+	}
+	return k
+}
 
-	b := x + 1.0 - a
-	c := 1.0 / fpmin
-	d := 1.0 / b
-	h := d
+func (s binomSampler) sampleInversion(r *rand.Rand) int {
+	x := 0
+	px := s.qn
+	u := uniform(r)
+	for u > px {
+		x++
+		if x > s.bound {
+			x = 0
+			px = s.qn
+			u = uniform(r)
+			continue
+		}
+		u -= px
+		px = float64(s.n-x+1) * s.pp * px / (float64(x) * s.q)
+	}
+	return x
+}
 
-	for i := 1; i <= itmax; i++ {
-		an := -float64(i) * (float64(i) - a)
-		b += 2.0
-		d = an*d + b
-		if math.Abs(d) < fpmin {
-			d = fpmin
+func (s binomSampler) sampleBTPE(r *rand.Rand) int {
+	for {
+		u := uniform(r) * s.p4
+		v := uniform(r)
+
+		var y int
+		switch {
+		case u <= s.p1:
+			// Región triangular: aceptación inmediata.
+			return int(math.Floor(s.xm - s.p1*v + u))
+		case u <= s.p2:
+			// Paralelogramos.
+			x := s.xl + (u-s.p1)/s.c
+			v = v*s.c + 1 - math.Abs(float64(s.m)-x+0.5)/s.p1
+			if v > 1 {
+				continue
+			}
+			y = int(math.Floor(x))
+		case u <= s.p3:
+			// Cola exponencial izquierda.
+			y = int(math.Floor(s.xl + math.Log(v)/s.laml))
+			if y < 0 {
+				continue
+			}
+			v *= (u - s.p2) * s.laml
+		default:
+			// Cola exponencial derecha.
+			y = int(math.Floor(s.xr - math.Log(v)/s.lamr))
+			if y > s.n {
+				continue
+			}
+			v *= (u - s.p3) * s.lamr
 		}
-		c = b + an/c
-		if math.Abs(c) < fpmin {
-			c = fpmin
+
+		// Aceptar si v <= f(y)/f(m).
+		k := y - s.m
+		if k < 0 {
+			k = -k
 		}
-		d = 1.0 / d
-		del := d * c
-		h *= del
-		if math.Abs(del-1.0) < eps {
-			break
+		if k <= 20 || float64(k) >= s.nrq/2-1 {
+			sr := s.pp / s.q
+			a := sr * float64(s.n+1)
+			f := 1.0
+			if s.m < y {
+				for i := s.m + 1; i <= y; i++ {
+					f *= a/float64(i) - sr
+				}
+			} else if s.m > y {
+				for i := y + 1; i <= s.m; i++ {
+					f /= a/float64(i) - sr
+				}
+			}
+			if v <= f {
+				return y
+			}
+			continue
 		}
-	}
 
-	return math.Exp(-x+a*math.Log(x)-logGamma(a)) * h
+		// Squeeze sobre log f(y)/f(m) antes de la evaluación exacta.
+		kf := float64(k)
+		rho := (kf / s.nrq) * ((kf*(kf/3+0.625)+0.1666666666666)/s.nrq + 0.5)
+		t := -kf * kf / (2 * s.nrq)
+		lv := math.Log(v)
+		if lv < t-rho {
+			return y
+		}
+		if lv > t+rho {
+			continue
+		}
+		if lv <= special.LogChoose(s.n, y)-special.LogChoose(s.n, s.m)+float64(y-s.m)*s.logRatioPQ {
+			return y
+		}
+	}
 }
 
 /* -----------------------------
-   Poisson PTRS (Hörmann, 1993)
+   Zipf RNG: rejection-inversion (Hörmann & Derflinger, 1996).
+   O(1) por muestra, sin tablas, para cualquier exponente s > 0.
 ------------------------------*/
 
-func poissonPTRS(lambda float64) int {
-	sqrtL := math.Sqrt(lambda)
-	logL := math.Log(lambda)
+type zipfSampler struct {
+	n                int
+	s                float64
+	hIntegralX1      float64
+	hIntegralN       float64
+	squeezeThreshold float64
+}
 
-	b := 0.931 + 2.53*sqrtL
-	a := -0.059 + 0.02483*b
-	invAlpha := 1.1239 + 1.1328/(b-3.4)
-	vR := 0.9277 - 3.6224/(b-2.0)
+func newZipfSampler(n int, s float64) zipfSampler {
+	z := zipfSampler{n: n, s: s}
+	z.hIntegralX1 = z.hIntegral(1.5) - 1
+	z.hIntegralN = z.hIntegral(float64(n) + 0.5)
+	z.squeezeThreshold = 2 - z.hIntegralInverse(z.hIntegral(2.5)-z.h(2))
+	return z
+}
 
+func (z zipfSampler) sample(r *rand.Rand) int {
 	for {
-		u := rand.Float64() - 0.5
-		v := rand.Float64()
-
-		us := 0.5 - math.Abs(u)
-		k := int(math.Floor((2*a/us+b)*u + lambda + 0.43))
-		if k < 0 {
-			continue
+		u := z.hIntegralN + uniform(r)*(z.hIntegralX1-z.hIntegralN)
+		x := z.hIntegralInverse(u)
+		k := int(x + 0.5)
+		if k < 1 {
+			k = 1
+		} else if k > z.n {
+			k = z.n
 		}
-
-		if us >= 0.07 && v <= vR {
+		kf := float64(k)
+		if kf-x <= z.squeezeThreshold || u >= z.hIntegral(kf+0.5)-z.h(kf) {
 			return k
 		}
+	}
+}
 
-		lhs := math.Log(v * invAlpha / (a/(us*us) + b))
-		rhs := float64(k)*logL - lambda - logFactorial(k)
-		if lhs <= rhs {
-			return k
+// h(x) = x^-s
+func (z zipfSampler) h(x float64) float64 {
+	return math.Exp(-z.s * math.Log(x))
+}
+
+// hIntegral es una primitiva de h: (x^(1-s) - 1) / (1-s), o ln x si s = 1.
+func (z zipfSampler) hIntegral(x float64) float64 {
+	logX := math.Log(x)
+	return expm1OverX((1-z.s)*logX) * logX
+}
+
+func (z zipfSampler) hIntegralInverse(x float64) float64 {
+	t := x * (1 - z.s)
+	if t < -1 {
+		t = -1
+	}
+	return math.Exp(log1pOverX(t) * x)
+}
+
+// log1pOverX devuelve log(1+x)/x, estable cerca de 0.
+func log1pOverX(x float64) float64 {
+	if math.Abs(x) > 1e-8 {
+		return math.Log1p(x) / x
+	}
+	return 1 - x*(0.5-x*(1.0/3-0.25*x))
+}
+
+// expm1OverX devuelve (e^x - 1)/x, estable cerca de 0.
+func expm1OverX(x float64) float64 {
+	if math.Abs(x) > 1e-8 {
+		return math.Expm1(x) / x
+	}
+	return 1 + x*0.5*(1+x/3*(1+0.25*x))
+}
+
+/* -----------------------------
+   Inversión numérica de una CDF:
+   expansión del intervalo y bisección.
+------------------------------*/
+
+// invertCDF busca el menor x con cdf(x) >= p para una CDF arbitraria.
+func invertCDF(cdf func(float64) float64, p float64) float64 {
+	switch {
+	case math.IsNaN(p) || p < 0 || p > 1:
+		return math.NaN()
+	case p == 0:
+		return math.Inf(-1)
+	case p == 1:
+		return math.Inf(1)
+	}
+	lo, hi := -1.0, 1.0
+	for cdf(lo) >= p && lo > -math.MaxFloat64/4 {
+		lo *= 2
+	}
+	for cdf(hi) < p && hi < math.MaxFloat64/4 {
+		hi *= 2
+	}
+	for range 2000 {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			break
+		}
+		if cdf(mid) >= p {
+			hi = mid
+		} else {
+			lo = mid
 		}
 	}
+	return hi
+}
+
+// quantileOf devuelve el cuantil p de d, invirtiendo su CDF numéricamente
+// cuando d no implementa Quantiler.
+func quantileOf(d Dist, p float64) float64 {
+	if q, ok := d.(Quantiler); ok {
+		return q.Quantile(p)
+	}
+	return invertCDF(d.CDF, p)
 }
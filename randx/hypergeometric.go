@@ -0,0 +1,226 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/miguelm-revel/revelTools/randx/special"
+)
+
+// HypergeometricDist counts the successes among Draws items drawn without
+// replacement from a population of N items, K of which are successes.
+type HypergeometricDist struct {
+	N, K, Draws int
+}
+
+// NewHypergeometric validates and returns
+// HypergeometricDist{N: n, K: k, Draws: draws}.
+func NewHypergeometric(n, k, draws int) (HypergeometricDist, error) {
+	d := HypergeometricDist{N: n, K: k, Draws: draws}
+	return d, d.Validate()
+}
+
+func (h HypergeometricDist) Validate() error {
+	if h.N < 0 {
+		return paramError("HypergeometricDist", "N", float64(h.N), ">= 0")
+	}
+	if h.K < 0 || h.K > h.N {
+		return paramError("HypergeometricDist", "K", float64(h.K), "in [0, N]")
+	}
+	if h.Draws < 0 || h.Draws > h.N {
+		return paramError("HypergeometricDist", "Draws", float64(h.Draws), "in [0, N]")
+	}
+	return nil
+}
+
+// support returns the smallest and largest attainable counts.
+func (h HypergeometricDist) support() (int, int) {
+	return max(0, h.Draws+h.K-h.N), min(h.Draws, h.K)
+}
+
+func (h HypergeometricDist) Rand() float64 {
+	return h.RandWith(nil)
+}
+
+// RandWith samples by inversion, starting at the mode and walking the PMF
+// recurrence outward in both directions. Starting at the lower end would
+// underflow for large populations, where the PMF there is far below the
+// smallest float64; from the mode the walk takes O(StdDev) steps.
+func (h HypergeometricDist) RandWith(r *rand.Rand) float64 {
+	if h.Validate() != nil {
+		return math.NaN()
+	}
+	lo, hi := h.support()
+	m := min(max(int(h.Mode()), lo), hi)
+	pm := h.PDF(float64(m))
+	u := uniform(r)
+	if u <= pm {
+		return float64(m)
+	}
+	u -= pm
+	rest := float64(h.N - h.K - h.Draws)
+	dn, up := m, m
+	pdn, pup := pm, pm
+	for dn > lo || up < hi {
+		if up < hi {
+			pup *= float64(h.K-up) * float64(h.Draws-up) / (float64(up+1) * (rest + float64(up+1)))
+			up++
+			if u <= pup {
+				return float64(up)
+			}
+			u -= pup
+		}
+		if dn > lo {
+			pdn *= float64(dn) * (rest + float64(dn)) / (float64(h.K-dn+1) * float64(h.Draws-dn+1))
+			dn--
+			if u <= pdn {
+				return float64(dn)
+			}
+			u -= pdn
+		}
+	}
+	// Rounding left u above the summed mass; the mode is the likeliest value.
+	return float64(m)
+}
+
+func (h HypergeometricDist) PDF(x float64) float64 {
+	if h.Validate() != nil {
+		return math.NaN()
+	}
+	k := int(math.Round(x))
+	lo, hi := h.support()
+	if float64(k) != x || k < lo || k > hi {
+		return 0
+	}
+	return math.Exp(special.LogChoose(h.K, k) + special.LogChoose(h.N-h.K, h.Draws-k) - special.LogChoose(h.N, h.Draws))
+}
+
+func (h HypergeometricDist) CDF(x float64) float64 {
+	if h.Validate() != nil || math.IsNaN(x) {
+		return math.NaN()
+	}
+	lo, hi := h.support()
+	k := math.Floor(x)
+	if k < float64(lo) {
+		return 0
+	}
+	if k >= float64(hi) {
+		return 1
+	}
+	sum := 0.0
+	for i := lo; i <= int(k); i++ {
+		sum += h.PDF(float64(i))
+	}
+	return math.Min(sum, 1)
+}
+
+func (h HypergeometricDist) Quantile(q float64) float64 {
+	if h.Validate() != nil || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	lo, hi := h.support()
+	cdf := func(k int) float64 { return h.CDF(float64(k)) }
+	guess := int(math.Floor(h.Mean() + h.StdDev()*special.NormQuantile(q)))
+	return float64(discreteQuantile(cdf, q, guess, lo, hi))
+}
+
+func (h HypergeometricDist) Mean() float64 {
+	if h.Validate() != nil || h.N == 0 {
+		return math.NaN()
+	}
+	return float64(h.Draws) * float64(h.K) / float64(h.N)
+}
+
+func (h HypergeometricDist) Variance() float64 {
+	if h.Validate() != nil || h.N == 0 {
+		return math.NaN()
+	}
+	if h.N == 1 {
+		return 0
+	}
+	n, k, d := float64(h.N), float64(h.K), float64(h.Draws)
+	return d * k / n * (n - k) / n * (n - d) / (n - 1)
+}
+
+func (h HypergeometricDist) StdDev() float64 {
+	return math.Sqrt(h.Variance())
+}
+
+// Skewness is NaN when K or Draws is 0 or N, where the distribution is a
+// point mass.
+func (h HypergeometricDist) Skewness() float64 {
+	if h.Validate() != nil || h.N <= 2 || h.Variance() == 0 {
+		return math.NaN()
+	}
+	n, k, d := float64(h.N), float64(h.K), float64(h.Draws)
+	return (n - 2*k) * math.Sqrt(n-1) * (n - 2*d) / (math.Sqrt(d*k*(n-k)*(n-d)) * (n - 2))
+}
+
+func (h HypergeometricDist) ExKurtosis() float64 {
+	if h.Validate() != nil || h.N <= 3 || h.Variance() == 0 {
+		return math.NaN()
+	}
+	n, k, d := float64(h.N), float64(h.K), float64(h.Draws)
+	num := (n-1)*n*n*(n*(n+1)-6*k*(n-k)-6*d*(n-d)) + 6*d*k*(n-k)*(n-d)*(5*n-6)
+	return num / (d * k * (n - k) * (n - d) * (n - 2) * (n - 3))
+}
+
+func (h HypergeometricDist) Mode() float64 {
+	if h.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Floor(float64(h.Draws+1) * float64(h.K+1) / float64(h.N+2))
+}
+
+func (h HypergeometricDist) Median() float64 {
+	return h.Quantile(0.5)
+}
+
+func (h HypergeometricDist) Entropy() float64 {
+	if h.Validate() != nil {
+		return math.NaN()
+	}
+	lo, hi := h.support()
+	pmf := func(k int) float64 { return h.PDF(float64(k)) }
+	return discreteEntropy(pmf, lo, hi)
+}
+
+func (h HypergeometricDist) LogPDF(x float64) float64 {
+	if h.Validate() != nil {
+		return math.NaN()
+	}
+	k := int(math.Round(x))
+	lo, hi := h.support()
+	if float64(k) != x || k < lo || k > hi {
+		return math.Inf(-1)
+	}
+	return special.LogChoose(h.K, k) + special.LogChoose(h.N-h.K, h.Draws-k) - special.LogChoose(h.N, h.Draws)
+}
+
+func (h HypergeometricDist) LogCDF(x float64) float64 {
+	return math.Log(h.CDF(x))
+}
+
+// Survival sums the upper tail directly rather than taking 1 - CDF.
+func (h HypergeometricDist) Survival(x float64) float64 {
+	if h.Validate() != nil || math.IsNaN(x) {
+		return math.NaN()
+	}
+	lo, hi := h.support()
+	k := math.Floor(x)
+	if k < float64(lo) {
+		return 1
+	}
+	if k >= float64(hi) {
+		return 0
+	}
+	sum := 0.0
+	for i := hi; i > int(k); i-- {
+		sum += h.PDF(float64(i))
+	}
+	return math.Min(sum, 1)
+}
+
+func (h HypergeometricDist) LogSurvival(x float64) float64 {
+	return math.Log(h.Survival(x))
+}
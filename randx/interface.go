@@ -1,7 +1,53 @@
 package randx
 
+import "math/rand/v2"
+
 type Dist interface {
 	Rand() float64
+	// RandWith draws a sample using r as the source of randomness. A nil r
+	// falls back to the package-level generator, exactly like Rand.
+	RandWith(r *rand.Rand) float64
 	PDF(x float64) float64
 	CDF(x float64) float64
 }
+
+// Quantiler is implemented by distributions that can evaluate their inverse
+// CDF. Quantile returns the smallest x such that CDF(x) >= p, and NaN when p
+// lies outside [0, 1].
+type Quantiler interface {
+	Quantile(p float64) float64
+}
+
+// Moments exposes the summary statistics of a distribution, computed in closed
+// form from the same parameters the distribution struct samples from.
+// Invalid parameters yield NaN, like the rest of the Dist methods.
+type Moments interface {
+	Mean() float64
+	Variance() float64
+	StdDev() float64
+	Skewness() float64
+	ExKurtosis() float64
+	Mode() float64
+	Median() float64
+	Entropy() float64
+}
+
+// MultiDist is a distribution over vectors of length Dim. LogPDF returns -Inf
+// outside the support and NaN for invalid parameters or a wrong-sized x.
+type MultiDist interface {
+	Dim() int
+	Rand() []float64
+	RandWith(r *rand.Rand) []float64
+	LogPDF(x []float64) float64
+}
+
+// LogDist is implemented by distributions that evaluate their density and
+// tails in log space, for likelihoods that must not underflow. Survival is
+// 1 - CDF computed without cancellation, so it stays accurate far into the
+// upper tail.
+type LogDist interface {
+	LogPDF(x float64) float64
+	LogCDF(x float64) float64
+	Survival(x float64) float64
+	LogSurvival(x float64) float64
+}
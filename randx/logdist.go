@@ -0,0 +1,37 @@
+package randx
+
+import "math"
+
+// LogPDF returns ln d.PDF(x), evaluated in log space when d implements LogDist.
+func LogPDF(d Dist, x float64) float64 {
+	if l, ok := d.(LogDist); ok {
+		return l.LogPDF(x)
+	}
+	return math.Log(d.PDF(x))
+}
+
+// LogCDF returns ln d.CDF(x), evaluated in log space when d implements LogDist.
+func LogCDF(d Dist, x float64) float64 {
+	if l, ok := d.(LogDist); ok {
+		return l.LogCDF(x)
+	}
+	return math.Log(d.CDF(x))
+}
+
+// Survival returns 1 - d.CDF(x), without cancellation when d implements
+// LogDist.
+func Survival(d Dist, x float64) float64 {
+	if l, ok := d.(LogDist); ok {
+		return l.Survival(x)
+	}
+	return 1 - d.CDF(x)
+}
+
+// LogSurvival returns ln(1 - d.CDF(x)), evaluated in log space when d
+// implements LogDist.
+func LogSurvival(d Dist, x float64) float64 {
+	if l, ok := d.(LogDist); ok {
+		return l.LogSurvival(x)
+	}
+	return math.Log1p(-d.CDF(x))
+}
@@ -0,0 +1,110 @@
+package randx
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+var logDists = []interface {
+	Dist
+	Quantiler
+	LogDist
+}{
+	NormalDist{Mu: 1, Sigma: 2},
+	ExpDist{Lambda: 1.5},
+	PoissonDist{Lambda: 4.2},
+	BinomDist{N: 30, P: 0.35},
+	Chi2Dist{K: 5},
+	GammaDist{Shape: 2.5, Scale: 1.5},
+	BetaDist{Alpha: 2, Beta: 3.5},
+	UniformDist{Min: -1, Max: 3},
+	LogNormalDist{Mu: 0.3, Sigma: 0.5},
+	StudentTDist{Nu: 4},
+	FDist{D1: 5, D2: 12},
+	WeibullDist{K: 1.7, Lambda: 2},
+	ParetoDist{Xm: 1, Alpha: 3},
+	CauchyDist{X0: 0.5, Gamma: 2},
+	BernoulliDist{P: 0.3},
+	GeometricDist{P: 0.2},
+	NegBinomialDist{R: 3.5, P: 0.4},
+	HypergeometricDist{N: 50, K: 20, Draws: 12},
+	DiscreteUniformDist{Min: -3, Max: 7},
+	ZipfDist{N: 40, S: 1.2},
+	CategoricalDist{Weights: []float64{1, 0, 3, 2}},
+}
+
+func TestLogDist_ConsistentWithPDFAndCDF(t *testing.T) {
+	near := func(got, want float64) bool {
+		if math.IsInf(want, 0) {
+			return got == want
+		}
+		return math.Abs(got-want) <= 1e-9*math.Max(1, math.Abs(want))
+	}
+	for _, d := range logDists {
+		name := fmt.Sprintf("%T%+v", d, d)
+		for _, p := range []float64{0.001, 0.05, 0.3, 0.5, 0.7, 0.95, 0.999} {
+			x := d.Quantile(p)
+			if got, want := d.LogPDF(x), math.Log(d.PDF(x)); !near(got, want) {
+				t.Errorf("%s: LogPDF(%v) = %v, want %v", name, x, got, want)
+			}
+			if got, want := d.LogCDF(x), math.Log(d.CDF(x)); !near(got, want) {
+				t.Errorf("%s: LogCDF(%v) = %v, want %v", name, x, got, want)
+			}
+			if got, want := d.Survival(x), 1-d.CDF(x); math.Abs(got-want) > 1e-9 {
+				t.Errorf("%s: Survival(%v) = %v, want %v", name, x, got, want)
+			}
+			if got, want := d.LogSurvival(x), math.Log(d.Survival(x)); !near(got, want) {
+				t.Errorf("%s: LogSurvival(%v) = %v, want %v", name, x, got, want)
+			}
+		}
+	}
+}
+
+// TestLogDist_FarTails checks values where the linear-space CDF has already
+// underflowed or rounded to 1.
+func TestLogDist_FarTails(t *testing.T) {
+	cases := []struct {
+		name      string
+		got, want float64
+		tol       float64
+	}{
+		// ln Φ(-50) = -1254.8313611...
+		{"normal LogCDF(-50)", NormalDist{Sigma: 1}.LogCDF(-50), -1254.8313611384958, 1e-9},
+		{"normal LogSurvival(50)", NormalDist{Sigma: 1}.LogSurvival(50), -1254.8313611384958, 1e-9},
+		{"exp LogSurvival(1000)", ExpDist{Lambda: 2}.LogSurvival(1000), -2000, 0},
+		{"weibull LogSurvival(100)", WeibullDist{K: 2, Lambda: 1}.LogSurvival(100), -10000, 0},
+		{"pareto LogSurvival(1e200)", ParetoDist{Xm: 1, Alpha: 3}.LogSurvival(1e200), -600 * math.Ln10, 1e-9},
+		{"geometric LogSurvival(1e4)", GeometricDist{P: 0.5}.LogSurvival(1e4), 10001 * math.Log(0.5), 1e-9},
+		{"gamma LogPDF(2000)", GammaDist{Shape: 1, Scale: 1}.LogPDF(2000), -2000, 1e-12},
+		// P(X > 60) for Poisson(2) is dominated by its first term.
+		{"poisson LogSurvival(60)", PoissonDist{Lambda: 2}.LogSurvival(60),
+			PoissonDist{Lambda: 2}.LogPDF(61) + math.Log1p(2.0/62+4.0/(62*63)+8.0/(62*63*64)), 1e-6},
+	}
+	for _, c := range cases {
+		if math.IsInf(c.got, 0) || math.IsNaN(c.got) || math.Abs(c.got-c.want) > c.tol*math.Abs(c.want) {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+	if s := (CauchyDist{Gamma: 1}).Survival(1e12); s <= 0 || math.Abs(s*math.Pi*1e12-1) > 1e-6 {
+		t.Errorf("cauchy Survival(1e12) = %v", s)
+	}
+	if p := (PoissonDist{Lambda: 0}).PDF(0); p != 1 {
+		t.Errorf("poisson(0) PDF(0) = %v, want 1", p)
+	}
+}
+
+func TestLogDist_FallbackForPlainDist(t *testing.T) {
+	d := plainDist{}
+	x := 0.7
+	if got, want := LogPDF(d, x), math.Log(d.PDF(x)); got != want {
+		t.Errorf("LogPDF = %v, want %v", got, want)
+	}
+	if got, want := Survival(d, x), 1-d.CDF(x); got != want {
+		t.Errorf("Survival = %v, want %v", got, want)
+	}
+	n := NormalDist{Sigma: 1}
+	if got := LogCDF(n, -40); got != n.LogCDF(-40) {
+		t.Errorf("LogCDF did not dispatch to LogDist: %v", got)
+	}
+}
@@ -0,0 +1,164 @@
+package randx
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// MixtureDist is a finite mixture: each draw picks component i with
+// probability proportional to Weights[i] and samples from it. PDF and CDF
+// are the weighted sums of the component PDFs and CDFs. Weights need not be
+// normalized but must be finite, non-negative and not all zero.
+type MixtureDist struct {
+	Components []Dist
+	Weights    []float64
+}
+
+// NewMixture validates and returns the mixture of dists with the given weights.
+// Both slices are copied and the weights are normalized.
+func NewMixture(dists []Dist, weights []float64) (MixtureDist, error) {
+	m := MixtureDist{Components: slices.Clone(dists), Weights: slices.Clone(weights)}
+	if err := m.Validate(); err != nil {
+		return m, err
+	}
+	sum := m.total()
+	for i := range m.Weights {
+		m.Weights[i] /= sum
+	}
+	return m, nil
+}
+
+func (m MixtureDist) Validate() error {
+	if len(m.Components) != len(m.Weights) {
+		return paramError("MixtureDist", "Weights", float64(len(m.Weights)), fmt.Sprintf("one per component (%d)", len(m.Components)))
+	}
+	if err := validateWeights("MixtureDist", "Weights", m.Weights); err != nil {
+		return err
+	}
+	for i, d := range m.Components {
+		if err := validateInner("MixtureDist", fmt.Sprintf("Components[%d]", i), d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// total returns the sum of the weights, or NaN if the mixture is invalid.
+func (m MixtureDist) total() float64 {
+	if m.Validate() != nil {
+		return math.NaN()
+	}
+	sum := 0.0
+	for _, w := range m.Weights {
+		sum += w
+	}
+	return sum
+}
+
+func (m MixtureDist) Rand() float64 {
+	return m.RandWith(nil)
+}
+
+func (m MixtureDist) RandWith(r *rand.Rand) float64 {
+	if m.Validate() != nil {
+		return math.NaN()
+	}
+	i := CategoricalDist{Weights: m.Weights}.RandWith(r)
+	return m.Components[int(i)].RandWith(r)
+}
+
+// FillWith builds an alias table over the weights once for the whole batch.
+func (m MixtureDist) FillWith(r *rand.Rand, dst []float64) {
+	if m.Validate() != nil {
+		fillNaN(dst)
+		return
+	}
+	pick, err := NewAlias(m.Weights)
+	if err != nil {
+		fillNaN(dst)
+		return
+	}
+	for i := range dst {
+		dst[i] = m.Components[pick.RandWith(r)].RandWith(r)
+	}
+}
+
+func (m MixtureDist) PDF(x float64) float64 {
+	total := m.total()
+	if math.IsNaN(total) {
+		return math.NaN()
+	}
+	sum := 0.0
+	for i, d := range m.Components {
+		if m.Weights[i] > 0 {
+			sum += m.Weights[i] * d.PDF(x)
+		}
+	}
+	return sum / total
+}
+
+func (m MixtureDist) CDF(x float64) float64 {
+	total := m.total()
+	if math.IsNaN(total) {
+		return math.NaN()
+	}
+	sum := 0.0
+	for i, d := range m.Components {
+		if m.Weights[i] > 0 {
+			sum += m.Weights[i] * d.CDF(x)
+		}
+	}
+	return math.Min(sum/total, 1)
+}
+
+// Quantile inverts the mixture CDF numerically.
+func (m MixtureDist) Quantile(p float64) float64 {
+	if m.Validate() != nil {
+		return math.NaN()
+	}
+	return invertCDF(m.CDF, p)
+}
+
+// Mean returns the weighted mean of the component means, or NaN unless every
+// component implements Moments.
+func (m MixtureDist) Mean() float64 {
+	total := m.total()
+	if math.IsNaN(total) {
+		return math.NaN()
+	}
+	mean := 0.0
+	for i, d := range m.Components {
+		dm, ok := d.(Moments)
+		if !ok {
+			return math.NaN()
+		}
+		if m.Weights[i] > 0 {
+			mean += m.Weights[i] * dm.Mean()
+		}
+	}
+	return mean / total
+}
+
+// Variance returns Σ wᵢ(σᵢ² + μᵢ²) - μ², or NaN unless every component
+// implements Moments.
+func (m MixtureDist) Variance() float64 {
+	mean := m.Mean()
+	if math.IsNaN(mean) {
+		return math.NaN()
+	}
+	second := 0.0
+	for i, d := range m.Components {
+		if m.Weights[i] > 0 {
+			dm := d.(Moments)
+			mu := dm.Mean()
+			second += m.Weights[i] * (dm.Variance() + mu*mu)
+		}
+	}
+	return second/m.total() - mean*mean
+}
+
+func (m MixtureDist) StdDev() float64 {
+	return math.Sqrt(m.Variance())
+}
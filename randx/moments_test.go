@@ -0,0 +1,109 @@
+package randx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMoments_DiscreteMatchPMF(t *testing.T) {
+	dists := map[string]interface {
+		Dist
+		Moments
+	}{
+		"poisson":  PoissonDist{Lambda: 6.5},
+		"binomial": BinomDist{N: 40, P: 0.35},
+	}
+	for name, d := range dists {
+		var m1, m2, m3, m4 float64
+		for k := 0; k < 200; k++ {
+			p := d.PDF(float64(k))
+			m1 += p * float64(k)
+		}
+		for k := 0; k < 200; k++ {
+			p := d.PDF(float64(k))
+			dk := float64(k) - m1
+			m2 += p * dk * dk
+			m3 += p * dk * dk * dk
+			m4 += p * dk * dk * dk * dk
+		}
+		checks := []struct {
+			what      string
+			got, want float64
+		}{
+			{"mean", d.Mean(), m1},
+			{"variance", d.Variance(), m2},
+			{"skewness", d.Skewness(), m3 / math.Pow(m2, 1.5)},
+			{"excess kurtosis", d.ExKurtosis(), m4/(m2*m2) - 3},
+		}
+		for _, c := range checks {
+			if math.Abs(c.got-c.want) > 1e-9 {
+				t.Errorf("%s %s: got %v, want %v", name, c.what, c.got, c.want)
+			}
+		}
+		mode := d.Mode()
+		if d.PDF(mode) < d.PDF(mode-1) || d.PDF(mode) < d.PDF(mode+1) {
+			t.Errorf("%s: Mode() = %v is not a local maximum of the PMF", name, mode)
+		}
+	}
+}
+
+func TestMoments_Entropy(t *testing.T) {
+	cases := []struct {
+		name      string
+		got, want float64
+	}{
+		// Chi2 with two degrees of freedom is Exp(1/2).
+		{"chi2(2)", Chi2Dist{K: 2}.Entropy(), ExpDist{Lambda: 0.5}.Entropy()},
+		{"normal", NormalDist{Mu: 0, Sigma: 1}.Entropy(), 1.4189385332046727},
+		{"poisson(1)", PoissonDist{Lambda: 1}.Entropy(), 1.3048422422562516},
+		{"binomial(1, 0.5)", BinomDist{N: 1, P: 0.5}.Entropy(), math.Ln2},
+		{"chi2(1)", Chi2Dist{K: 1}.Entropy(), 0.7837571104739337},
+	}
+	for _, c := range cases {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestMoments_LargeEntropy(t *testing.T) {
+	// Just above the thresholds the asymptotic forms agree with the sums.
+	pois := PoissonDist{Lambda: 1000}
+	sum := discreteEntropy(func(k int) float64 { return pois.PDF(float64(k)) }, 500, 1500)
+	if got := pois.Entropy(); math.Abs(got-sum) > 1e-10 {
+		t.Errorf("poisson(1000): got %v, want %v", got, sum)
+	}
+	binom := BinomDist{N: 50000, P: 0.3}
+	sum = discreteEntropy(func(k int) float64 { return binom.PDF(float64(k)) }, 13000, 17000)
+	if got := binom.Entropy(); math.Abs(got-sum) > 1e-9 {
+		t.Errorf("binomial(50000, 0.3): got %v, want %v", got, sum)
+	}
+	// Far above them they stay cheap and finite.
+	if h := (PoissonDist{Lambda: 1e15}).Entropy(); math.IsNaN(h) || math.IsInf(h, 0) {
+		t.Errorf("poisson(1e15) entropy = %v", h)
+	}
+	if h := (BinomDist{N: math.MaxInt, P: 0.5}).Entropy(); math.IsNaN(h) || math.IsInf(h, 0) {
+		t.Errorf("binomial(MaxInt, 0.5) entropy = %v", h)
+	}
+}
+
+func TestMoments_PointMassShape(t *testing.T) {
+	for name, d := range map[string]Moments{
+		"poisson(0)":      PoissonDist{Lambda: 0},
+		"binomial(5, 0)":  BinomDist{N: 5, P: 0},
+		"binomial(5, 1)":  BinomDist{N: 5, P: 1},
+		"bernoulli(0)":    BernoulliDist{P: 0},
+		"bernoulli(1)":    BernoulliDist{P: 1},
+		"geometric(1)":    GeometricDist{P: 1},
+		"negbinomial(1)":  NegBinomialDist{R: 2.5, P: 1},
+		"hypergeom K=0":   HypergeometricDist{N: 10, K: 0, Draws: 4},
+		"hypergeom all":   HypergeometricDist{N: 10, K: 3, Draws: 10},
+		"uniform{3}":      DiscreteUniformDist{Min: 3, Max: 3},
+		"categorical one": CategoricalDist{Weights: []float64{0, 2, 0}},
+		"zipf(1)":         ZipfDist{N: 1, S: 2},
+	} {
+		if s, k := d.Skewness(), d.ExKurtosis(); !math.IsNaN(s) || !math.IsNaN(k) {
+			t.Errorf("%s: skewness %v, excess kurtosis %v, want NaN", name, s, k)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package randx
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"runtime"
+
+	"github.com/miguelm-revel/revelTools/syncx"
+)
+
+// mcChunk is the number of independent samples per job. Work is split into
+// fixed-size chunks, each with its own stream, so results depend only on the
+// seed and not on the number of workers.
+const mcChunk = 1 << 12
+
+// MonteCarlo configures a parallel Monte Carlo run. The zero value of every
+// field except N selects a default.
+type MonteCarlo struct {
+	// N is the number of evaluations of the sampling function.
+	N int
+	// Workers is the number of goroutines; 0 means runtime.GOMAXPROCS(0).
+	Workers int
+	// Seed selects the random streams; a run is reproducible from it.
+	Seed uint64
+	// Level is the confidence level of the interval; 0 means 0.95.
+	Level float64
+	// Antithetic pairs every evaluation with one that sees the bitwise
+	// complement of its random stream, so r.Float64() returns roughly 1 - u,
+	// quantile-based draws such as FromUniform mirror across the median and
+	// the package's normal sampler returns -z. The pair average is one sample. The estimate stays unbiased for any
+	// sampling function, but variance drops only for functions monotone in
+	// their uniforms.
+	Antithetic bool
+}
+
+// Estimate is the result of a Monte Carlo run. [Lo, Hi] is a Student-t
+// confidence interval, and N counts the independent samples behind it: N
+// evaluations, or N/2 pairs with antithetic variates.
+type Estimate struct {
+	Value, StdErr float64
+	Lo, Hi        float64
+	N             int
+}
+
+// Run estimates E[f] with f drawing its randomness only from r.
+func (mc MonteCarlo) Run(f func(r *rand.Rand) float64) (Estimate, error) {
+	acc, err := mc.run(func(r *rand.Rand) (float64, float64) { return f(r), 0 })
+	if err != nil {
+		return Estimate{}, err
+	}
+	return mc.estimate(acc.meanY, acc.m2Y/(acc.n-1), acc.n, acc.n-1), nil
+}
+
+// RunControl estimates E[y] for f returning (y, c), where c is a control
+// variate correlated with y whose exact mean is controlMean. The estimator is
+// mean(y) - β(mean(c) - controlMean), with β fitted by least squares over the
+// same samples.
+func (mc MonteCarlo) RunControl(f func(r *rand.Rand) (y, c float64), controlMean float64) (Estimate, error) {
+	acc, err := mc.run(f)
+	if err != nil {
+		return Estimate{}, err
+	}
+	if acc.n < 3 || acc.m2C == 0 {
+		return Estimate{}, errors.New("randx: montecarlo: control variate needs at least 3 samples with non-zero variance")
+	}
+	beta := acc.cYC / acc.m2C
+	value := acc.meanY - beta*(acc.meanC-controlMean)
+	resid := (acc.m2Y - beta*acc.cYC) / (acc.n - 2)
+	return mc.estimate(value, math.Max(resid, 0), acc.n, acc.n-2), nil
+}
+
+func (mc MonteCarlo) estimate(value, variance, n, df float64) Estimate {
+	level := mc.Level
+	if level == 0 {
+		level = 0.95
+	}
+	se := math.Sqrt(variance / n)
+	half := StudentTDist{Nu: df}.Quantile(0.5+level/2) * se
+	return Estimate{Value: value, StdErr: se, Lo: value - half, Hi: value + half, N: int(n)}
+}
+
+// run evaluates f in chunks on a worker pool and merges the per-chunk
+// co-moments in chunk order.
+func (mc MonteCarlo) run(f func(r *rand.Rand) (float64, float64)) (coMoments, error) {
+	samples := mc.N
+	if mc.Antithetic {
+		samples /= 2
+	}
+	if samples < 2 {
+		return coMoments{}, errors.New("randx: montecarlo: need at least two independent samples")
+	}
+	if !(mc.Level >= 0 && mc.Level < 1) {
+		return coMoments{}, errors.New("randx: montecarlo: confidence level must be in (0, 1)")
+	}
+	parts := make([]coMoments, (samples+mcChunk-1)/mcChunk)
+	forChunks(mc.Workers, samples, mcChunk, func(chunk, lo, hi int) {
+		parts[chunk] = mc.chunk(f, uint64(chunk), hi-lo)
+	})
+
+	var acc coMoments
+	for _, p := range parts {
+		acc.merge(p)
+	}
+	return acc, nil
+}
+
+func (mc MonteCarlo) chunk(f func(r *rand.Rand) (float64, float64), index uint64, n int) coMoments {
+	var acc coMoments
+	if !mc.Antithetic {
+		r := chunkRand(mc.Seed, index)
+		for range n {
+			acc.add(f(r))
+		}
+		return acc
+	}
+	// Each pair reseeds both streams, so a sampler that consumes a different
+	// number of values on either side cannot leak into the next pair.
+	seed := splitMix64(mc.Seed ^ splitMix64(index))
+	src, mirror := rand.NewPCG(0, 0), rand.NewPCG(0, 0)
+	r, rAnti := rand.New(src), rand.New(complementSource{mirror})
+	for k := range uint64(n) {
+		s1, s2 := seed, splitMix64(seed+k)
+		src.Seed(s1, s2)
+		mirror.Seed(s1, s2)
+		y1, c1 := f(r)
+		y2, c2 := f(rAnti)
+		acc.add(0.5*(y1+y2), 0.5*(c1+c2))
+	}
+	return acc
+}
+
+// forChunks splits [0, total) into consecutive chunks of the given size and
+// runs job on each from a pool of workers goroutines, or runtime.GOMAXPROCS(0)
+// when workers <= 0. Chunk boundaries do not depend on workers, so pairing
+// each chunk with chunkRand makes parallel results reproducible.
+func forChunks(workers, total, size int, job func(chunk, lo, hi int)) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	pool := syncx.NewWorkerPool(workers, workers)
+	for c := 0; c*size < total; c++ {
+		pool.Submit(func() error {
+			job(c, c*size, min((c+1)*size, total))
+			return nil
+		})
+	}
+	pool.Close()
+	pool.Wait()
+}
+
+// chunkRand returns the random stream of chunk index under seed.
+func chunkRand(seed, index uint64) *rand.Rand {
+	s := splitMix64(seed ^ splitMix64(index))
+	return rand.New(rand.NewPCG(s, splitMix64(s)))
+}
+
+// complementSource yields the bitwise complement of its source, which is
+// again uniform and maps each Float64 u to about 1 - u.
+type complementSource struct{ rand.Source }
+
+func (c complementSource) Uint64() uint64 {
+	return ^c.Source.Uint64()
+}
+
+// splitMix64 is the finalizer of the SplitMix64 generator, used to derive
+// well-separated stream seeds from small integers.
+func splitMix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// coMoments accumulates the means, second central moments and co-moment of
+// pairs (y, c) with Welford's update and Chan's pairwise merge.
+type coMoments struct {
+	n            float64
+	meanY, meanC float64
+	m2Y, m2C     float64
+	cYC          float64
+}
+
+func (a *coMoments) add(y, c float64) {
+	a.n++
+	dy := y - a.meanY
+	dc := c - a.meanC
+	a.meanY += dy / a.n
+	a.meanC += dc / a.n
+	a.m2Y += dy * (y - a.meanY)
+	a.m2C += dc * (c - a.meanC)
+	a.cYC += dy * (c - a.meanC)
+}
+
+func (a *coMoments) merge(b coMoments) {
+	if b.n == 0 {
+		return
+	}
+	n := a.n + b.n
+	dy := b.meanY - a.meanY
+	dc := b.meanC - a.meanC
+	w := a.n * b.n / n
+	a.m2Y += b.m2Y + dy*dy*w
+	a.m2C += b.m2C + dc*dc*w
+	a.cYC += b.cYC + dy*dc*w
+	a.meanY += dy * b.n / n
+	a.meanC += dc * b.n / n
+	a.n = n
+}
@@ -0,0 +1,115 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestMonteCarlo_Integral(t *testing.T) {
+	// ∫₀¹ eᵘ du = e - 1, with Var[eᵁ] = (e² - 1)/2 - (e - 1)² ≈ 0.242.
+	want := math.E - 1
+	f := func(r *rand.Rand) float64 { return math.Exp(r.Float64()) }
+	plain, err := MonteCarlo{N: 100000, Seed: 1}.Run(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plain.N != 100000 || math.Abs(plain.Value-want) > 4*plain.StdErr {
+		t.Errorf("plain estimate %+v, want %v", plain, want)
+	}
+	if se := math.Sqrt(0.2420356075) / math.Sqrt(100000); math.Abs(plain.StdErr-se) > 0.05*se {
+		t.Errorf("plain StdErr = %v, want ≈ %v", plain.StdErr, se)
+	}
+	if !(plain.Lo < want && want < plain.Hi) {
+		t.Errorf("interval [%v, %v] misses %v", plain.Lo, plain.Hi, want)
+	}
+	if half := 1.96 * plain.StdErr; math.Abs(plain.Hi-plain.Value-half) > 1e-3*half {
+		t.Errorf("95%% half-width = %v, want ≈ %v", plain.Hi-plain.Value, half)
+	}
+
+	anti, err := MonteCarlo{N: 100000, Seed: 1, Antithetic: true}.Run(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if anti.N != 50000 || math.Abs(anti.Value-want) > 4*anti.StdErr {
+		t.Errorf("antithetic estimate %+v, want %v", anti, want)
+	}
+	// Same evaluation budget, but eᵘ is monotone so the pairs are strongly
+	// negatively correlated.
+	if anti.StdErr > plain.StdErr/5 {
+		t.Errorf("antithetic StdErr %v not well below plain %v", anti.StdErr, plain.StdErr)
+	}
+
+	ctl, err := MonteCarlo{N: 100000, Seed: 1}.RunControl(func(r *rand.Rand) (float64, float64) {
+		u := r.Float64()
+		return math.Exp(u), u
+	}, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(ctl.Value-want) > 4*ctl.StdErr || ctl.StdErr > plain.StdErr/5 {
+		t.Errorf("control variate estimate %+v, plain StdErr %v", ctl, plain.StdErr)
+	}
+}
+
+func TestMonteCarlo_AntitheticNormal(t *testing.T) {
+	// The complemented stream makes NormalDist.RandWith return the mirrored
+	// draw, so the pair average of X is almost constant.
+	d := NormalDist{Mu: 2, Sigma: 3}
+	f := func(r *rand.Rand) float64 { return d.RandWith(r) }
+	plain, err := MonteCarlo{N: 100000, Seed: 5}.Run(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	anti, err := MonteCarlo{N: 100000, Seed: 5, Antithetic: true}.Run(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(anti.Value-2) > 4*anti.StdErr {
+		t.Errorf("antithetic estimate %+v, want 2", anti)
+	}
+	if anti.StdErr > plain.StdErr/5 {
+		t.Errorf("antithetic StdErr %v not well below plain %v", anti.StdErr, plain.StdErr)
+	}
+}
+
+func TestMonteCarlo_ReproducibleAcrossWorkers(t *testing.T) {
+	f := func(r *rand.Rand) float64 { return NormalDist{Mu: 2, Sigma: 1}.RandWith(r) }
+	for _, anti := range []bool{false, true} {
+		var first Estimate
+		for i, workers := range []int{1, 3, 8} {
+			est, err := MonteCarlo{N: 30001, Workers: workers, Seed: 42, Antithetic: anti}.Run(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if i == 0 {
+				first = est
+			} else if est != first {
+				t.Errorf("antithetic=%v: %d workers gave %+v, 1 worker gave %+v", anti, workers, est, first)
+			}
+		}
+	}
+	first, _ := MonteCarlo{N: 30001, Seed: 42}.Run(f)
+	other, _ := MonteCarlo{N: 30001, Seed: 43}.Run(f)
+	if other.Value == first.Value {
+		t.Error("different seeds gave the same estimate")
+	}
+}
+
+func TestMonteCarlo_Invalid(t *testing.T) {
+	f := func(r *rand.Rand) float64 { return r.Float64() }
+	for name, mc := range map[string]MonteCarlo{
+		"too few":          {N: 1},
+		"too few pairs":    {N: 3, Antithetic: true},
+		"confidence level": {N: 10, Level: 1},
+		"NaN level":        {N: 10, Level: math.NaN()},
+	} {
+		if _, err := mc.Run(f); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	constant := func(r *rand.Rand) (float64, float64) { return r.Float64(), 1 }
+	if _, err := (MonteCarlo{N: 10}).RunControl(constant, 1); err == nil {
+		t.Error("expected an error for a constant control")
+	}
+}
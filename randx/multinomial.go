@@ -0,0 +1,111 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+
+	"github.com/miguelm-revel/revelTools/randx/special"
+)
+
+// MultinomialDist counts how N independent draws from the categories with
+// probabilities P fall into each category. P need not be normalized but must
+// be finite, non-negative and not all zero.
+type MultinomialDist struct {
+	N int
+	P []float64
+}
+
+// NewMultinomial validates and returns MultinomialDist{N: n, P: p}. The
+// probabilities are copied.
+func NewMultinomial(n int, p []float64) (MultinomialDist, error) {
+	d := MultinomialDist{N: n, P: slices.Clone(p)}
+	return d, d.Validate()
+}
+
+func (m MultinomialDist) Validate() error {
+	if m.N < 0 {
+		return paramError("MultinomialDist", "N", float64(m.N), ">= 0")
+	}
+	return validateWeights("MultinomialDist", "P", m.P)
+}
+
+// total returns the sum of P, or NaN if the parameters are invalid.
+func (m MultinomialDist) total() float64 {
+	if m.N < 0 {
+		return math.NaN()
+	}
+	return CategoricalDist{Weights: m.P}.total()
+}
+
+func (m MultinomialDist) Dim() int {
+	return len(m.P)
+}
+
+func (m MultinomialDist) Rand() []float64 {
+	return m.RandWith(nil)
+}
+
+// RandWith draws each count from the binomial conditional on the counts
+// already assigned, so the cost is O(Dim) rather than O(N).
+func (m MultinomialDist) RandWith(r *rand.Rand) []float64 {
+	x := make([]float64, len(m.P))
+	total := m.total()
+	if math.IsNaN(total) {
+		fillNaN(x)
+		return x
+	}
+	left := m.N
+	mass := total
+	for i, p := range m.P {
+		if left == 0 {
+			break
+		}
+		if i == len(m.P)-1 || p >= mass {
+			x[i] = float64(left)
+			break
+		}
+		k := newBinomSampler(left, p/mass).sample(r)
+		x[i] = float64(k)
+		left -= k
+		mass -= p
+	}
+	return x
+}
+
+func (m MultinomialDist) LogPDF(x []float64) float64 {
+	total := m.total()
+	if math.IsNaN(total) || len(x) != len(m.P) {
+		return math.NaN()
+	}
+	logf := special.LogFactorial(m.N)
+	n := 0
+	for i, xi := range x {
+		k := int(math.Round(xi))
+		if float64(k) != xi || k < 0 {
+			return math.Inf(-1)
+		}
+		n += k
+		if k == 0 {
+			continue
+		}
+		if m.P[i] == 0 {
+			return math.Inf(-1)
+		}
+		logf += float64(k)*math.Log(m.P[i]/total) - special.LogFactorial(k)
+	}
+	if n != m.N {
+		return math.Inf(-1)
+	}
+	return logf
+}
+
+// Mean returns N·Pᵢ / ΣP for every category.
+func (m MultinomialDist) Mean() []float64 {
+	mean := make([]float64, len(m.P))
+	total := m.total()
+	for i, p := range m.P {
+		mean[i] = float64(m.N) * p / total
+	}
+	return mean
+}
@@ -0,0 +1,194 @@
+package randx
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+var (
+	_ MultiDist = MultivariateNormalDist{}
+	_ MultiDist = DirichletDist{}
+	_ MultiDist = MultinomialDist{}
+)
+
+// sampleMoments returns the sample mean and covariance of n draws from d.
+func sampleMoments(d MultiDist, n int, seed uint64) ([]float64, [][]float64) {
+	k := d.Dim()
+	r := NewRand(seed)
+	xs := make([][]float64, n)
+	mean := make([]float64, k)
+	for i := range xs {
+		xs[i] = d.RandWith(r)
+		for j, v := range xs[i] {
+			mean[j] += v / float64(n)
+		}
+	}
+	cov := make([][]float64, k)
+	for a := range cov {
+		cov[a] = make([]float64, k)
+		for _, x := range xs {
+			for b := range cov[a] {
+				cov[a][b] += (x[a] - mean[a]) * (x[b] - mean[b]) / float64(n-1)
+			}
+		}
+	}
+	return mean, cov
+}
+
+func TestMultivariateNormal_Moments(t *testing.T) {
+	mean := []float64{1, -2, 0.5}
+	cov := [][]float64{
+		{4, 1.2, -0.6},
+		{1.2, 1, 0.3},
+		{-0.6, 0.3, 0.5},
+	}
+	d, err := NewMultivariateNormal(mean, cov)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	gotMean, gotCov := sampleMoments(d, 200000, 2)
+	for i := range mean {
+		if math.Abs(gotMean[i]-mean[i]) > 0.03 {
+			t.Errorf("mean[%d] = %v, want %v", i, gotMean[i], mean[i])
+		}
+		for j := range mean {
+			if math.Abs(gotCov[i][j]-cov[i][j]) > 0.05 {
+				t.Errorf("cov[%d][%d] = %v, want %v", i, j, gotCov[i][j], cov[i][j])
+			}
+			if rebuilt := d.Cov()[i][j]; math.Abs(rebuilt-cov[i][j]) > 1e-12 {
+				t.Errorf("Cov()[%d][%d] = %v, want %v", i, j, rebuilt, cov[i][j])
+			}
+		}
+	}
+}
+
+func TestMultivariateNormal_LogPDFDiagonal(t *testing.T) {
+	d, err := NewMultivariateNormal([]float64{0, 3}, [][]float64{{4, 0}, {0, 0.25}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	x := []float64{1.5, 2.2}
+	want := math.Log(NormalDist{Mu: 0, Sigma: 2}.PDF(1.5) * NormalDist{Mu: 3, Sigma: 0.5}.PDF(2.2))
+	if got := d.LogPDF(x); math.Abs(got-want) > 1e-12 {
+		t.Fatalf("LogPDF = %v, want %v", got, want)
+	}
+}
+
+func TestMultivariateNormal_InvalidCovariance(t *testing.T) {
+	cases := map[string][][]float64{
+		"not positive-definite": {{1, 2}, {2, 1}},
+		"not symmetric":         {{1, 0.5}, {0.1, 1}},
+		"wrong size":            {{1, 0}},
+	}
+	for name, cov := range cases {
+		if _, err := NewMultivariateNormal([]float64{0, 0}, cov); !errors.Is(err, ErrInvalidParameter) {
+			t.Errorf("%s: error = %v", name, err)
+		}
+		d := MultivariateNormalDist{Mu: []float64{0, 0}, Sigma: cov}
+		if x := d.RandWith(NewRand(1)); !math.IsNaN(x[0]) {
+			t.Errorf("%s: sample %v, want NaN", name, x)
+		}
+	}
+	// A literal factors Sigma on demand and agrees with the constructor.
+	lit := MultivariateNormalDist{Mu: []float64{0, 3}, Sigma: [][]float64{{4, 1}, {1, 0.5}}}
+	built, err := NewMultivariateNormal(lit.Mu, lit.Sigma)
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := []float64{1, 2}
+	if lit.LogPDF(x) != built.LogPDF(x) {
+		t.Errorf("literal LogPDF %v, constructed %v", lit.LogPDF(x), built.LogPDF(x))
+	}
+	// Editing the exported fields after construction bypasses the cache.
+	built.Sigma[0][0] = 9
+	lit.Sigma = [][]float64{{9, 1}, {1, 0.5}}
+	if lit.LogPDF(x) != built.LogPDF(x) {
+		t.Errorf("after editing Sigma: literal LogPDF %v, constructed %v", lit.LogPDF(x), built.LogPDF(x))
+	}
+	built.Sigma[1][0] = 5
+	if got := built.LogPDF(x); !math.IsNaN(got) {
+		t.Errorf("after making Sigma asymmetric: LogPDF = %v, want NaN", got)
+	}
+	built.Sigma[1][0] = 1
+	built.Mu = []float64{math.NaN(), 3}
+	if got := built.Mean(); !math.IsNaN(got[0]) || !math.IsNaN(got[1]) {
+		t.Errorf("after setting Mu to NaN: Mean = %v, want NaN", got)
+	}
+}
+
+func TestDirichlet(t *testing.T) {
+	d := DirichletDist{Alpha: []float64{2, 5, 0.5}}
+	mean, _ := sampleMoments(d, 100000, 3)
+	for i, want := range d.Mean() {
+		if math.Abs(mean[i]-want) > 0.005 {
+			t.Errorf("mean[%d] = %v, want %v", i, mean[i], want)
+		}
+	}
+	// Dirichlet(a, b) on (x, 1-x) is Beta(a, b).
+	two := DirichletDist{Alpha: []float64{2, 3.5}}
+	want := math.Log(BetaDist{Alpha: 2, Beta: 3.5}.PDF(0.3))
+	if got := two.LogPDF([]float64{0.3, 0.7}); math.Abs(got-want) > 1e-12 {
+		t.Errorf("LogPDF = %v, want %v", got, want)
+	}
+	if got := two.LogPDF([]float64{0.3, 0.3}); !math.IsInf(got, -1) {
+		t.Errorf("LogPDF off the simplex = %v, want -Inf", got)
+	}
+	// Dirichlet(1, 1, 1) is uniform on the simplex, including its boundary.
+	flat := DirichletDist{Alpha: []float64{1, 1, 1}}
+	for _, x := range [][]float64{{0.2, 0.3, 0.5}, {0, 0.4, 0.6}, {1, 0, 0}} {
+		if got := flat.LogPDF(x); math.Abs(got-math.Log(2)) > 1e-12 {
+			t.Errorf("flat LogPDF(%v) = %v, want ln 2", x, got)
+		}
+	}
+}
+
+func TestDirichlet_SmallConcentrations(t *testing.T) {
+	// Every gamma draw underflows at these concentrations; samples must still
+	// lie on the simplex.
+	d := DirichletDist{Alpha: []float64{0.001, 0.001, 0.002}}
+	r := NewRand(8)
+	for range 10000 {
+		x := d.RandWith(r)
+		sum := 0.0
+		for _, v := range x {
+			if !(v >= 0 && v <= 1) {
+				t.Fatalf("invalid sample %v", x)
+			}
+			sum += v
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Fatalf("sample %v sums to %v", x, sum)
+		}
+	}
+}
+
+func TestMultinomial(t *testing.T) {
+	d := MultinomialDist{N: 40, P: []float64{1, 0, 2, 7}}
+	r := NewRand(5)
+	for range 1000 {
+		x := d.RandWith(r)
+		sum := 0.0
+		for _, v := range x {
+			sum += v
+		}
+		if sum != 40 || x[1] != 0 {
+			t.Fatalf("invalid sample %v", x)
+		}
+	}
+	mean, _ := sampleMoments(d, 100000, 6)
+	for i, want := range d.Mean() {
+		if math.Abs(mean[i]-want) > 0.05 {
+			t.Errorf("mean[%d] = %v, want %v", i, mean[i], want)
+		}
+	}
+	// With two categories the counts are binomial.
+	two := MultinomialDist{N: 12, P: []float64{0.3, 0.7}}
+	want := math.Log(BinomDist{N: 12, P: 0.3}.PDF(5))
+	if got := two.LogPDF([]float64{5, 7}); math.Abs(got-want) > 1e-12 {
+		t.Errorf("LogPDF = %v, want %v", got, want)
+	}
+	if got := two.LogPDF([]float64{5, 6}); !math.IsInf(got, -1) {
+		t.Errorf("LogPDF with wrong total = %v, want -Inf", got)
+	}
+}
@@ -0,0 +1,212 @@
+package randx
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// MultivariateNormalDist is the normal distribution with mean vector Mu and a
+// symmetric positive-definite covariance matrix Sigma. Samples are drawn as
+// μ + L·z, where L is the Cholesky factor of Sigma and z is a vector of
+// independent standard normals.
+type MultivariateNormalDist struct {
+	Mu    []float64
+	Sigma [][]float64
+
+	// chol and logDet cache the factorization of sigma, a private copy of
+	// Sigma. NewMultivariateNormal fills them; a distribution built as a
+	// literal, or whose Sigma no longer matches sigma, factors Sigma on every
+	// call.
+	sigma  [][]float64
+	chol   [][]float64
+	logDet float64
+}
+
+// NewMultivariateNormal validates and returns
+// MultivariateNormalDist{Mu: mu, Sigma: sigma} with the Cholesky factor of
+// sigma cached. Both are copied.
+func NewMultivariateNormal(mu []float64, sigma [][]float64) (MultivariateNormalDist, error) {
+	m := MultivariateNormalDist{Mu: slices.Clone(mu), Sigma: make([][]float64, len(sigma))}
+	for i, row := range sigma {
+		m.Sigma[i] = slices.Clone(row)
+	}
+	chol, logDet, err := m.factor()
+	if err != nil {
+		return m, err
+	}
+	m.sigma = make([][]float64, len(m.Sigma))
+	for i, row := range m.Sigma {
+		m.sigma[i] = slices.Clone(row)
+	}
+	m.chol, m.logDet = chol, logDet
+	return m, nil
+}
+
+func (m MultivariateNormalDist) Validate() error {
+	n := len(m.Mu)
+	if n == 0 {
+		return paramError("MultivariateNormalDist", "Mu", 0, "at least one component")
+	}
+	for i, mu := range m.Mu {
+		if !finite(mu) {
+			return paramError("MultivariateNormalDist", fmt.Sprintf("Mu[%d]", i), mu, "finite")
+		}
+	}
+	if len(m.Sigma) != n {
+		return paramError("MultivariateNormalDist", "Sigma", float64(len(m.Sigma)), fmt.Sprintf("%d rows", n))
+	}
+	for i, row := range m.Sigma {
+		if len(row) != n {
+			return paramError("MultivariateNormalDist", fmt.Sprintf("Sigma[%d]", i), float64(len(row)), fmt.Sprintf("%d columns", n))
+		}
+		for j := 0; j < i; j++ {
+			if math.Abs(row[j]-m.Sigma[j][i]) > 1e-12*math.Max(math.Abs(row[j]), 1) {
+				return paramError("MultivariateNormalDist", fmt.Sprintf("Sigma[%d][%d]", i, j), row[j], fmt.Sprintf("= Sigma[%d][%d]", j, i))
+			}
+		}
+	}
+	if _, ok := cholesky(m.Sigma); !ok {
+		return paramError("MultivariateNormalDist", "Sigma", math.NaN(), "positive-definite")
+	}
+	return nil
+}
+
+// factor returns the Cholesky factor of Sigma and ln det Sigma, from the cache
+// while it still describes m.
+func (m MultivariateNormalDist) factor() ([][]float64, float64, error) {
+	if m.cached() {
+		return m.chol, m.logDet, nil
+	}
+	if err := m.Validate(); err != nil {
+		return nil, 0, err
+	}
+	chol, _ := cholesky(m.Sigma)
+	logDet := 0.0
+	for i := range chol {
+		logDet += 2 * math.Log(chol[i][i])
+	}
+	return chol, logDet, nil
+}
+
+// cached reports whether the constructor's factorization still applies: Mu is
+// finite and of the same length, and Sigma is equal to the matrix that was
+// factored. Checking this costs O(n²) against the O(n³) of a new factorization.
+func (m MultivariateNormalDist) cached() bool {
+	if m.chol == nil || len(m.Mu) != len(m.sigma) || len(m.Sigma) != len(m.sigma) {
+		return false
+	}
+	for i, mu := range m.Mu {
+		if !finite(mu) || !slices.Equal(m.Sigma[i], m.sigma[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// cholesky returns the lower-triangular L with L·Lᵀ = a, and false if a is not
+// positive-definite.
+func cholesky(a [][]float64) ([][]float64, bool) {
+	n := len(a)
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, i+1)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			sum := a[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if sum <= 0 || math.IsNaN(sum) {
+					return nil, false
+				}
+				l[i][i] = math.Sqrt(sum)
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return l, true
+}
+
+func (m MultivariateNormalDist) Dim() int {
+	return len(m.Mu)
+}
+
+// Mean returns a copy of Mu.
+func (m MultivariateNormalDist) Mean() []float64 {
+	mean := slices.Clone(m.Mu)
+	if _, _, err := m.factor(); err != nil {
+		fillNaN(mean)
+	}
+	return mean
+}
+
+// Cov returns the covariance matrix, rebuilt from its Cholesky factor.
+func (m MultivariateNormalDist) Cov() [][]float64 {
+	n := len(m.Mu)
+	chol, _, err := m.factor()
+	cov := make([][]float64, n)
+	for i := range cov {
+		cov[i] = make([]float64, n)
+		if err != nil {
+			fillNaN(cov[i])
+			continue
+		}
+		for j := range cov[i] {
+			for k := 0; k <= min(i, j); k++ {
+				cov[i][j] += chol[i][k] * chol[j][k]
+			}
+		}
+	}
+	return cov
+}
+
+func (m MultivariateNormalDist) Rand() []float64 {
+	return m.RandWith(nil)
+}
+
+func (m MultivariateNormalDist) RandWith(r *rand.Rand) []float64 {
+	n := len(m.Mu)
+	x := make([]float64, n)
+	chol, _, err := m.factor()
+	if err != nil {
+		fillNaN(x)
+		return x
+	}
+	z := make([]float64, n)
+	for i := range z {
+		z[i] = stdNormal(r)
+	}
+	for i := range x {
+		sum := m.Mu[i]
+		for k := 0; k <= i; k++ {
+			sum += chol[i][k] * z[k]
+		}
+		x[i] = sum
+	}
+	return x
+}
+
+func (m MultivariateNormalDist) LogPDF(x []float64) float64 {
+	n := len(m.Mu)
+	chol, logDet, err := m.factor()
+	if err != nil || len(x) != n {
+		return math.NaN()
+	}
+	// Solve L·z = x - μ by forward substitution; the Mahalanobis distance is |z|².
+	z := make([]float64, n)
+	dist := 0.0
+	for i := 0; i < n; i++ {
+		sum := x[i] - m.Mu[i]
+		for k := 0; k < i; k++ {
+			sum -= chol[i][k] * z[k]
+		}
+		z[i] = sum / chol[i][i]
+		dist += z[i] * z[i]
+	}
+	return -0.5 * (float64(n)*math.Log(2*math.Pi) + logDet + dist)
+}
@@ -0,0 +1,277 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/miguelm-revel/revelTools/randx/special"
+)
+
+// NegBinomialDist counts the failures before the R-th success of independent
+// trials with success probability P. R may be any positive real, which makes
+// it the Gamma–Poisson mixture with shape R and mean R(1-P)/P.
+type NegBinomialDist struct {
+	R, P float64
+}
+
+// NewNegBinomial validates and returns NegBinomialDist{R: r, P: p}.
+func NewNegBinomial(r, p float64) (NegBinomialDist, error) {
+	d := NegBinomialDist{R: r, P: p}
+	return d, d.Validate()
+}
+
+func (n NegBinomialDist) Validate() error {
+	if !positive(n.R) {
+		return paramError("NegBinomialDist", "R", n.R, "> 0")
+	}
+	if !(n.P > 0 && n.P <= 1) {
+		return paramError("NegBinomialDist", "P", n.P, "in (0, 1]")
+	}
+	return nil
+}
+
+func (n NegBinomialDist) Rand() float64 {
+	return n.RandWith(nil)
+}
+
+func (n NegBinomialDist) RandWith(r *rand.Rand) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	if n.P == 1 {
+		return 0
+	}
+	lambda := gammaRand(r, n.R) * (1 - n.P) / n.P
+	return PoissonDist{Lambda: lambda}.RandWith(r)
+}
+
+func (n NegBinomialDist) PDF(x float64) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	k := int(math.Round(x))
+	if float64(k) != x || k < 0 {
+		return 0
+	}
+	if n.P == 1 {
+		if k == 0 {
+			return 1
+		}
+		return 0
+	}
+	kf := float64(k)
+	logC := special.LogGamma(kf+n.R) - special.LogFactorial(k) - special.LogGamma(n.R)
+	return math.Exp(logC + n.R*math.Log(n.P) + kf*math.Log1p(-n.P))
+}
+
+func (n NegBinomialDist) CDF(x float64) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 0 {
+		return 0
+	}
+	if math.IsInf(k, 1) {
+		return 1
+	}
+	// P(X <= k) = I_p(r, k+1)
+	return special.RegIncBeta(n.R, k+1, n.P)
+}
+
+func (n NegBinomialDist) Quantile(q float64) float64 {
+	if n.Validate() != nil || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	if q == 0 || n.P == 1 {
+		return 0
+	}
+	if q == 1 {
+		return math.Inf(1)
+	}
+	guess := int(math.Floor(n.Mean() + n.StdDev()*special.NormQuantile(q)))
+	cdf := func(k int) float64 { return n.CDF(float64(k)) }
+	return float64(discreteQuantile(cdf, q, guess, 0, math.MaxInt))
+}
+
+func (n NegBinomialDist) Mean() float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	return n.R * (1 - n.P) / n.P
+}
+
+func (n NegBinomialDist) Variance() float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	return n.R * (1 - n.P) / (n.P * n.P)
+}
+
+func (n NegBinomialDist) StdDev() float64 {
+	return math.Sqrt(n.Variance())
+}
+
+// Skewness is NaN when P is 1, where the distribution is a point mass.
+func (n NegBinomialDist) Skewness() float64 {
+	if n.Validate() != nil || n.Variance() == 0 {
+		return math.NaN()
+	}
+	return (2 - n.P) / math.Sqrt(n.R*(1-n.P))
+}
+
+func (n NegBinomialDist) ExKurtosis() float64 {
+	if n.Validate() != nil || n.Variance() == 0 {
+		return math.NaN()
+	}
+	return 6/n.R + n.P*n.P/(n.R*(1-n.P))
+}
+
+func (n NegBinomialDist) Mode() float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	if n.R <= 1 {
+		return 0
+	}
+	return math.Floor((n.R - 1) * (1 - n.P) / n.P)
+}
+
+func (n NegBinomialDist) Median() float64 {
+	return n.Quantile(0.5)
+}
+
+func (n NegBinomialDist) Entropy() float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	// The tail decays like e^-βk; mass beyond 40 standard deviations, or
+	// beyond 45/β for small R, does not change the sum in float64.
+	mean, sd := n.Mean(), math.Sqrt(n.R*(1-n.P))/n.P
+	beta := -math.Log1p(-n.P)
+	hi := math.Max(mean+40*sd+40, 45/beta)
+	pmf := func(k int) float64 { return n.PDF(float64(k)) }
+	if hi < negBinomHead {
+		return discreteEntropy(pmf, 0, int(hi))
+	}
+	// Past the first negBinomHead terms the PMF varies slowly, so the rest of
+	// the sum is the integral of -p ln p over its continuous extension. The
+	// sum over k >= negBinomHead is the midpoint rule for that integral from
+	// negBinomHead-½, whose leading error g'/24 is added back. The integral is
+	// taken in log space so a tiny P costs no more than a moderate one.
+	h := discreteEntropy(pmf, 0, negBinomHead-1)
+	a := math.Max(negBinomHead-0.5, mean-40*sd)
+	g := func(x float64) float64 {
+		lp := n.logPMFTail(x)
+		return -math.Exp(lp) * lp
+	}
+	if a == negBinomHead-0.5 {
+		const dx = 1e-3
+		h += (g(a+dx) - g(a-dx)) / (2 * dx) / 24
+	}
+	return h + simpsonLog(g, a, hi, 20000)
+}
+
+// negBinomHead is the number of PMF terms Entropy sums exactly before it
+// switches to integrating the rest.
+const negBinomHead = 1 << 14
+
+// logPMFTail returns ln P(X = x) for real x >= negBinomHead-1, where
+// Stirling's series gives ln Γ(x+R) - ln Γ(x+1) without the cancellation of
+// subtracting the two.
+func (n NegBinomialDist) logPMFTail(x float64) float64 {
+	r1 := n.R - 1
+	lr := (x+0.5)*math.Log1p(r1/(x+1)) + r1*math.Log(x+n.R) - r1 + 1/(12*(x+n.R)) - 1/(12*(x+1))
+	return lr - special.LogGamma(n.R) + n.R*math.Log(n.P) + x*math.Log1p(-n.P)
+}
+
+// simpsonLog integrates f over [a, b], with 0 < a < b, by the composite
+// Simpson rule in t = ln x over intervals steps (even).
+func simpsonLog(f func(float64) float64, a, b float64, intervals int) float64 {
+	if !(a < b) {
+		return 0
+	}
+	lo, hi := math.Log(a), math.Log(b)
+	step := (hi - lo) / float64(intervals)
+	g := func(t float64) float64 {
+		x := math.Exp(t)
+		return f(x) * x
+	}
+	sum := g(lo) + g(hi)
+	for i := 1; i < intervals; i++ {
+		w := 2.0
+		if i%2 == 1 {
+			w = 4
+		}
+		sum += w * g(lo+float64(i)*step)
+	}
+	return sum * step / 3
+}
+
+func (n NegBinomialDist) LogPDF(x float64) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	k := int(math.Round(x))
+	if float64(k) != x || k < 0 {
+		return math.Inf(-1)
+	}
+	if n.P == 1 {
+		if k == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	kf := float64(k)
+	logC := special.LogGamma(kf+n.R) - special.LogFactorial(k) - special.LogGamma(n.R)
+	return logC + n.R*math.Log(n.P) + kf*math.Log1p(-n.P)
+}
+
+func (n NegBinomialDist) LogCDF(x float64) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 0 {
+		return math.Inf(-1)
+	}
+	if math.IsInf(k, 1) {
+		return 0
+	}
+	return special.LogRegIncBeta(n.R, k+1, n.P)
+}
+
+// Survival returns P(X > k) = I_{1-p}(k+1, r).
+func (n NegBinomialDist) Survival(x float64) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 0 {
+		return 1
+	}
+	if math.IsInf(k, 1) {
+		return 0
+	}
+	if n.P == 1 {
+		return 0
+	}
+	return special.RegIncBeta(k+1, n.R, 1-n.P)
+}
+
+func (n NegBinomialDist) LogSurvival(x float64) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 0 {
+		return 0
+	}
+	if math.IsInf(k, 1) {
+		return math.Inf(-1)
+	}
+	if n.P == 1 {
+		return math.Inf(-1)
+	}
+	return special.LogRegIncBeta(k+1, n.R, 1-n.P)
+}
@@ -3,33 +3,43 @@ package randx
 import (
 	"math"
 	"math/rand/v2"
+
+	"github.com/miguelm-revel/revelTools/randx/special"
 )
 
 type NormalDist struct {
 	Mu, Sigma float64
 }
 
-func (n NormalDist) Rand() float64 {
-	if n.Sigma <= 0 {
-		return math.NaN()
-	}
+// NewNormal validates and returns NormalDist{Mu: mu, Sigma: sigma}.
+func NewNormal(mu, sigma float64) (NormalDist, error) {
+	d := NormalDist{Mu: mu, Sigma: sigma}
+	return d, d.Validate()
+}
 
-	u1 := rand.Float64()
-	if u1 == 0 {
-		u1 = math.SmallestNonzeroFloat64
+func (n NormalDist) Validate() error {
+	if !finite(n.Mu) {
+		return paramError("NormalDist", "Mu", n.Mu, "finite")
 	}
-	u2 := rand.Float64()
-
-	r := math.Sqrt(-2.0 * math.Log(u1))
-	theta := 2.0 * math.Pi * u2
+	if !positive(n.Sigma) {
+		return paramError("NormalDist", "Sigma", n.Sigma, "> 0")
+	}
+	return nil
+}
 
-	z0 := r * math.Cos(theta)
+func (n NormalDist) Rand() float64 {
+	return n.RandWith(nil)
+}
 
-	return n.Mu + n.Sigma*z0
+func (n NormalDist) RandWith(r *rand.Rand) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	return n.Mu + n.Sigma*stdNormal(r)
 }
 
 func (n NormalDist) PDF(x float64) float64 {
-	if n.Sigma <= 0 {
+	if n.Validate() != nil {
 		return math.NaN()
 	}
 	z := (x - n.Mu) / n.Sigma
@@ -37,9 +47,113 @@ func (n NormalDist) PDF(x float64) float64 {
 }
 
 func (n NormalDist) CDF(x float64) float64 {
-	if n.Sigma <= 0 {
+	if n.Validate() != nil {
 		return math.NaN()
 	}
 	z := (x - n.Mu) / (n.Sigma * math.Sqrt2)
 	return 0.5 * (1.0 + math.Erf(z))
 }
+
+func (n NormalDist) Quantile(p float64) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	return n.Mu + n.Sigma*special.NormQuantile(p)
+}
+
+func (n NormalDist) Mean() float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	return n.Mu
+}
+
+func (n NormalDist) Variance() float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	return n.Sigma * n.Sigma
+}
+
+func (n NormalDist) StdDev() float64 {
+	return math.Sqrt(n.Variance())
+}
+
+func (n NormalDist) Skewness() float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	return 0
+}
+
+func (n NormalDist) ExKurtosis() float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	return 0
+}
+
+func (n NormalDist) Mode() float64 {
+	return n.Mean()
+}
+
+func (n NormalDist) Median() float64 {
+	return n.Mean()
+}
+
+func (n NormalDist) Entropy() float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	return 0.5 * math.Log(2*math.Pi*math.E*n.Sigma*n.Sigma)
+}
+
+func (n NormalDist) Sample(k int) []float64 {
+	dst := make([]float64, k)
+	n.Fill(dst)
+	return dst
+}
+
+func (n NormalDist) Fill(dst []float64) {
+	n.FillWith(nil, dst)
+}
+
+func (n NormalDist) FillWith(r *rand.Rand, dst []float64) {
+	if n.Validate() != nil {
+		fillNaN(dst)
+		return
+	}
+	for i := range dst {
+		dst[i] = n.Mu + n.Sigma*stdNormal(r)
+	}
+}
+
+func (n NormalDist) LogPDF(x float64) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	z := (x - n.Mu) / n.Sigma
+	return -0.5*z*z - math.Log(n.Sigma) - 0.5*math.Log(2.0*math.Pi)
+}
+
+func (n NormalDist) LogCDF(x float64) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	return special.LogNormCDF((x - n.Mu) / n.Sigma)
+}
+
+func (n NormalDist) Survival(x float64) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	z := (x - n.Mu) / (n.Sigma * math.Sqrt2)
+	return 0.5 * math.Erfc(z)
+}
+
+func (n NormalDist) LogSurvival(x float64) float64 {
+	if n.Validate() != nil {
+		return math.NaN()
+	}
+	return special.LogNormCDF((n.Mu - x) / n.Sigma)
+}
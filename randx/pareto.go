@@ -0,0 +1,158 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// ParetoDist is the type I Pareto distribution with scale Xm and shape Alpha.
+type ParetoDist struct {
+	Xm, Alpha float64
+}
+
+// NewPareto validates and returns ParetoDist{Xm: xm, Alpha: alpha}.
+func NewPareto(xm, alpha float64) (ParetoDist, error) {
+	d := ParetoDist{Xm: xm, Alpha: alpha}
+	return d, d.Validate()
+}
+
+func (p ParetoDist) Validate() error {
+	if !positive(p.Xm) {
+		return paramError("ParetoDist", "Xm", p.Xm, "> 0")
+	}
+	if !positive(p.Alpha) {
+		return paramError("ParetoDist", "Alpha", p.Alpha, "> 0")
+	}
+	return nil
+}
+
+func (p ParetoDist) Rand() float64 {
+	return p.RandWith(nil)
+}
+
+func (p ParetoDist) RandWith(r *rand.Rand) float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	return p.Xm * math.Exp(stdExp(r)/p.Alpha)
+}
+
+func (p ParetoDist) PDF(x float64) float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Exp(p.LogPDF(x))
+}
+
+func (p ParetoDist) CDF(x float64) float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= p.Xm {
+		return 0
+	}
+	return -math.Expm1(p.Alpha * math.Log(p.Xm/x))
+}
+
+func (p ParetoDist) Quantile(q float64) float64 {
+	if p.Validate() != nil || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	return p.Xm * math.Exp(-math.Log1p(-q)/p.Alpha)
+}
+
+// Mean is infinite for Alpha <= 1.
+func (p ParetoDist) Mean() float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	if p.Alpha <= 1 {
+		return math.Inf(1)
+	}
+	return p.Alpha * p.Xm / (p.Alpha - 1)
+}
+
+// Variance is infinite for Alpha <= 2.
+func (p ParetoDist) Variance() float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	if p.Alpha <= 2 {
+		return math.Inf(1)
+	}
+	a1 := p.Alpha - 1
+	return p.Xm * p.Xm * p.Alpha / (a1 * a1 * (p.Alpha - 2))
+}
+
+func (p ParetoDist) StdDev() float64 {
+	return math.Sqrt(p.Variance())
+}
+
+// Skewness is undefined (NaN) for Alpha <= 3.
+func (p ParetoDist) Skewness() float64 {
+	if p.Validate() != nil || p.Alpha <= 3 {
+		return math.NaN()
+	}
+	return 2 * (1 + p.Alpha) / (p.Alpha - 3) * math.Sqrt((p.Alpha-2)/p.Alpha)
+}
+
+// ExKurtosis is undefined (NaN) for Alpha <= 4.
+func (p ParetoDist) ExKurtosis() float64 {
+	if p.Validate() != nil || p.Alpha <= 4 {
+		return math.NaN()
+	}
+	a := p.Alpha
+	return 6 * (a*a*a + a*a - 6*a - 2) / (a * (a - 3) * (a - 4))
+}
+
+func (p ParetoDist) Mode() float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	return p.Xm
+}
+
+func (p ParetoDist) Median() float64 {
+	return p.Quantile(0.5)
+}
+
+func (p ParetoDist) Entropy() float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Log(p.Xm/p.Alpha) + 1/p.Alpha + 1
+}
+
+func (p ParetoDist) LogPDF(x float64) float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	if x < p.Xm {
+		return math.Inf(-1)
+	}
+	return math.Log(p.Alpha/x) + p.Alpha*math.Log(p.Xm/x)
+}
+
+func (p ParetoDist) LogCDF(x float64) float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= p.Xm {
+		return math.Inf(-1)
+	}
+	return math.Log(-math.Expm1(p.Alpha * math.Log(p.Xm/x)))
+}
+
+func (p ParetoDist) Survival(x float64) float64 {
+	return math.Exp(p.LogSurvival(x))
+}
+
+func (p ParetoDist) LogSurvival(x float64) float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= p.Xm {
+		return 0
+	}
+	return p.Alpha * math.Log(p.Xm/x)
+}
@@ -3,14 +3,33 @@ package randx
 import (
 	"math"
 	"math/rand/v2"
+
+	"github.com/miguelm-revel/revelTools/randx/special"
 )
 
 type PoissonDist struct {
 	Lambda float64
 }
 
+// NewPoisson validates and returns PoissonDist{Lambda: lambda}.
+func NewPoisson(lambda float64) (PoissonDist, error) {
+	d := PoissonDist{Lambda: lambda}
+	return d, d.Validate()
+}
+
+func (p PoissonDist) Validate() error {
+	if !(p.Lambda >= 0) || math.IsInf(p.Lambda, 1) {
+		return paramError("PoissonDist", "Lambda", p.Lambda, ">= 0")
+	}
+	return nil
+}
+
 func (p PoissonDist) Rand() float64 {
-	if p.Lambda < 0 {
+	return p.RandWith(nil)
+}
+
+func (p PoissonDist) RandWith(r *rand.Rand) float64 {
+	if p.Validate() != nil {
 		return math.NaN()
 	}
 	if p.Lambda == 0 {
@@ -22,34 +41,200 @@ func (p PoissonDist) Rand() float64 {
 		prod := 1.0
 		for prod > L {
 			k++
-			prod *= rand.Float64()
+			prod *= uniform(r)
 		}
 		return float64(k - 1)
 	}
-	return float64(poissonPTRS(p.Lambda))
+	return float64(poissonPTRS(r, p.Lambda))
 }
 
 func (p PoissonDist) PDF(x float64) float64 {
-	if p.Lambda < 0 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Exp(p.LogPDF(x))
+}
+
+func (p PoissonDist) CDF(x float64) float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 0 {
+		return 0
+	}
+	if math.IsInf(k, 1) {
+		return 1
+	}
+	// P(X <= k) = Q(k+1, λ), the regularized upper incomplete gamma.
+	return special.RegUpperGamma(k+1, p.Lambda)
+}
+
+func (p PoissonDist) Quantile(q float64) float64 {
+	if p.Validate() != nil || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	if q == 0 || p.Lambda == 0 {
+		return 0
+	}
+	if q == 1 {
+		return math.Inf(1)
+	}
+	guess := int(math.Floor(p.Lambda + math.Sqrt(p.Lambda)*special.NormQuantile(q)))
+	cdf := func(k int) float64 { return p.CDF(float64(k)) }
+	return float64(discreteQuantile(cdf, q, guess, 0, math.MaxInt))
+}
+
+func (p PoissonDist) Mean() float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	return p.Lambda
+}
+
+func (p PoissonDist) Variance() float64 {
+	return p.Mean()
+}
+
+func (p PoissonDist) StdDev() float64 {
+	return math.Sqrt(p.Variance())
+}
+
+// Skewness is NaN for λ = 0, where the distribution is a point mass.
+func (p PoissonDist) Skewness() float64 {
+	if p.Validate() != nil || p.Lambda == 0 {
+		return math.NaN()
+	}
+	return 1 / math.Sqrt(p.Lambda)
+}
+
+func (p PoissonDist) ExKurtosis() float64 {
+	if p.Validate() != nil || p.Lambda == 0 {
+		return math.NaN()
+	}
+	return 1 / p.Lambda
+}
+
+func (p PoissonDist) Mode() float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Floor(p.Lambda)
+}
+
+func (p PoissonDist) Median() float64 {
+	return p.Quantile(0.5)
+}
+
+func (p PoissonDist) Entropy() float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	// For large λ the sum costs O(√λ) and gains nothing over the asymptotic
+	// series, whose next term is below 1e-12 at this threshold.
+	if l := p.Lambda; l >= 1000 {
+		return 0.5*math.Log(2*math.Pi*math.E*l) - 1/(12*l) - 1/(24*l*l) - 19/(360*l*l*l)
+	}
+	// Mass beyond 12 standard deviations does not change the sum in float64.
+	w := 12*math.Sqrt(p.Lambda) + 10
+	lo := int(math.Max(0, math.Floor(p.Lambda-w)))
+	hi := int(math.Ceil(p.Lambda + w))
+	pmf := func(k int) float64 { return p.PDF(float64(k)) }
+	return discreteEntropy(pmf, lo, hi)
+}
+
+func (p PoissonDist) Sample(n int) []float64 {
+	dst := make([]float64, n)
+	p.Fill(dst)
+	return dst
+}
+
+func (p PoissonDist) Fill(dst []float64) {
+	p.FillWith(nil, dst)
+}
+
+// FillWith computes exp(-λ) or the PTRS constants once for the whole batch.
+func (p PoissonDist) FillWith(r *rand.Rand, dst []float64) {
+	switch {
+	case p.Validate() != nil:
+		fillNaN(dst)
+	case p.Lambda == 0:
+		clear(dst)
+	case p.Lambda < 30:
+		L := math.Exp(-p.Lambda)
+		for i := range dst {
+			k := 0
+			prod := 1.0
+			for prod > L {
+				k++
+				prod *= uniform(r)
+			}
+			dst[i] = float64(k - 1)
+		}
+	default:
+		s := newPTRS(p.Lambda)
+		for i := range dst {
+			dst[i] = float64(s.sample(r))
+		}
+	}
+}
+
+func (p PoissonDist) LogPDF(x float64) float64 {
+	if p.Validate() != nil {
 		return math.NaN()
 	}
 	k := int(math.Round(x))
-	if float64(k) != x {
+	if float64(k) != x || k < 0 {
+		return math.Inf(-1)
+	}
+	if p.Lambda == 0 {
+		if k == 0 {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	return float64(k)*math.Log(p.Lambda) - p.Lambda - special.LogFactorial(k)
+}
+
+func (p PoissonDist) LogCDF(x float64) float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 0 {
+		return math.Inf(-1)
+	}
+	if math.IsInf(k, 1) {
 		return 0
 	}
+	return special.LogRegUpperGamma(k+1, p.Lambda)
+}
+
+// Survival returns P(X > x) = P(k+1, λ).
+func (p PoissonDist) Survival(x float64) float64 {
+	if p.Validate() != nil {
+		return math.NaN()
+	}
+	k := math.Floor(x)
 	if k < 0 {
+		return 1
+	}
+	if math.IsInf(k, 1) {
 		return 0
 	}
-	return math.Exp(float64(k)*math.Log(p.Lambda) - p.Lambda - logFactorial(k))
+	return special.RegLowerGamma(k+1, p.Lambda)
 }
 
-func (p PoissonDist) CDF(x float64) float64 {
-	if p.Lambda < 0 {
+func (p PoissonDist) LogSurvival(x float64) float64 {
+	if p.Validate() != nil {
 		return math.NaN()
 	}
-	k := int(math.Floor(x))
+	k := math.Floor(x)
 	if k < 0 {
 		return 0
 	}
-	return regLowerGamma(float64(k+1), p.Lambda)
+	if math.IsInf(k, 1) {
+		return math.Inf(-1)
+	}
+	return special.LogRegLowerGamma(k+1, p.Lambda)
 }
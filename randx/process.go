@@ -0,0 +1,150 @@
+package randx
+
+import (
+	"errors"
+	"iter"
+	"math"
+	"math/rand/v2"
+)
+
+// The generators below draw from r, or from the package-level generator when
+// r is nil, so a seeded r from NewRand reproduces the same path. Sequences
+// are lazy and, unless bounded by a horizon, infinite: stop ranging over them
+// with break. Invalid parameters yield an empty sequence.
+
+// PoissonProcess yields the arrival times in (0, horizon] of a homogeneous
+// Poisson process with the given rate, whose inter-arrival gaps are
+// exponential. Pass math.Inf(1) for an endless process.
+func PoissonProcess(r *rand.Rand, rate, horizon float64) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		if !(rate > 0) || math.IsInf(rate, 1) {
+			return
+		}
+		t := 0.0
+		for {
+			t += stdExp(r) / rate
+			if t > horizon || !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// ThinnedPoissonProcess yields the arrival times in (0, horizon] of a
+// non-homogeneous Poisson process with intensity rate(t), by Lewis and
+// Shedler's thinning: candidates from a homogeneous process with rate maxRate
+// are kept with probability rate(t)/maxRate. rate must not exceed maxRate;
+// larger values are treated as maxRate.
+func ThinnedPoissonProcess(r *rand.Rand, rate func(t float64) float64, maxRate, horizon float64) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		for t := range PoissonProcess(r, maxRate, horizon) {
+			if uniform(r)*maxRate < rate(t) && !yield(t) {
+				return
+			}
+		}
+	}
+}
+
+// GaussianWalk yields the positions x0, x1, x2, ... of a random walk whose
+// steps are independent N(mu, sigma²) draws.
+func GaussianWalk(r *rand.Rand, x0, mu, sigma float64) iter.Seq[float64] {
+	return func(yield func(float64) bool) {
+		if !(sigma >= 0) {
+			return
+		}
+		for x := x0; yield(x); {
+			x += mu + sigma*stdNormal(r)
+		}
+	}
+}
+
+// GeometricBrownianMotion yields (t, S_t) at t = 0, dt, 2·dt, ... for
+// dS = mu·S dt + sigma·S dW, starting at s0. Each step uses the exact
+// log-normal transition, so there is no discretization error at the grid
+// points.
+func GeometricBrownianMotion(r *rand.Rand, s0, mu, sigma, dt float64) iter.Seq2[float64, float64] {
+	return func(yield func(float64, float64) bool) {
+		if !(sigma >= 0) || !(dt > 0) {
+			return
+		}
+		drift := (mu - 0.5*sigma*sigma) * dt
+		vol := sigma * math.Sqrt(dt)
+		s := s0
+		for i := 0; yield(float64(i)*dt, s); i++ {
+			s *= math.Exp(drift + vol*stdNormal(r))
+		}
+	}
+}
+
+// OrnsteinUhlenbeck yields (t, X_t) at t = 0, dt, 2·dt, ... for the
+// mean-reverting process dX = theta·(mu - X) dt + sigma dW, starting at x0,
+// using the exact Gaussian transition between grid points.
+func OrnsteinUhlenbeck(r *rand.Rand, x0, theta, mu, sigma, dt float64) iter.Seq2[float64, float64] {
+	return func(yield func(float64, float64) bool) {
+		if !(theta > 0) || !(sigma >= 0) || !(dt > 0) {
+			return
+		}
+		decay := math.Exp(-theta * dt)
+		sd := sigma * math.Sqrt(-math.Expm1(-2*theta*dt)/(2*theta))
+		x := x0
+		for i := 0; yield(float64(i)*dt, x); i++ {
+			x = mu + (x-mu)*decay + sd*stdNormal(r)
+		}
+	}
+}
+
+// MarkovChain is a discrete-time Markov chain on the states 0..n-1. Each row
+// of the transition matrix is sampled with its own alias table, so a step
+// costs O(1). It is read-only after construction and safe for concurrent use.
+type MarkovChain struct {
+	rows []*Alias
+}
+
+// NewMarkovChain builds a chain from the transition matrix p, where p[i][j]
+// is the weight of moving from state i to state j. Rows are normalized
+// independently, so each must be non-negative and not all zero.
+func NewMarkovChain(p [][]float64) (*MarkovChain, error) {
+	n := len(p)
+	if n == 0 {
+		return nil, errors.New("randx: markov: empty transition matrix")
+	}
+	rows := make([]*Alias, n)
+	for i, row := range p {
+		if len(row) != n {
+			return nil, errors.New("randx: markov: transition matrix is not square")
+		}
+		a, err := NewAlias(row)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = a
+	}
+	return &MarkovChain{rows: rows}, nil
+}
+
+// States returns the number of states.
+func (m *MarkovChain) States() int {
+	return len(m.rows)
+}
+
+// Step draws the state that follows state, or returns -1 if state lies
+// outside 0..States()-1.
+func (m *MarkovChain) Step(r *rand.Rand, state int) int {
+	if state < 0 || state >= len(m.rows) {
+		return -1
+	}
+	return m.rows[state].RandWith(r)
+}
+
+// Walk yields start followed by the successive states of the chain. A start
+// outside 0..States()-1 yields an empty sequence.
+func (m *MarkovChain) Walk(r *rand.Rand, start int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		if start < 0 || start >= len(m.rows) {
+			return
+		}
+		for s := start; yield(s); {
+			s = m.rows[s].RandWith(r)
+		}
+	}
+}
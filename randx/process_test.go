@@ -0,0 +1,200 @@
+package randx
+
+import (
+	"iter"
+	"math"
+	"slices"
+	"testing"
+)
+
+// nth returns the value of seq after n steps, or NaN if it ends first.
+func nth(seq iter.Seq2[float64, float64], n int) float64 {
+	i := 0
+	for _, x := range seq {
+		if i == n {
+			return x
+		}
+		i++
+	}
+	return math.NaN()
+}
+
+func TestPoissonProcess(t *testing.T) {
+	r := NewRand(1)
+	const rate, horizon = 3.0, 20000.0
+	var gaps []float64
+	prev := 0.0
+	for at := range PoissonProcess(r, rate, horizon) {
+		if at <= prev || at > horizon {
+			t.Fatalf("arrival %v after %v outside (0, %v]", at, prev, horizon)
+		}
+		gaps = append(gaps, at-prev)
+		prev = at
+	}
+	// The count is Poisson(rate·horizon), with standard deviation ≈ 245.
+	if n := float64(len(gaps)); math.Abs(n-rate*horizon) > 5*math.Sqrt(rate*horizon) {
+		t.Errorf("%v arrivals, want ≈ %v", n, rate*horizon)
+	}
+	if stat := ksStatistic(gaps, ExpDist{Lambda: rate}.CDF); stat > ksCritical(len(gaps)) {
+		t.Errorf("inter-arrival KS statistic %v", stat)
+	}
+
+	// Stopping early must not draw further arrivals.
+	var first []float64
+	for at := range PoissonProcess(NewRand(2), 1, math.Inf(1)) {
+		first = append(first, at)
+		if len(first) == 3 {
+			break
+		}
+	}
+	again := slices.Collect(PoissonProcess(NewRand(2), 1, first[2]))
+	if !slices.Equal(first, again) {
+		t.Errorf("seeded processes differ: %v vs %v", first, again)
+	}
+	for range PoissonProcess(r, 0, 10) {
+		t.Fatal("a zero rate should yield no arrivals")
+	}
+}
+
+func TestThinnedPoissonProcess(t *testing.T) {
+	// Intensity 2t on [0, 10] has expected count ∫ 2t dt = 100 and arrival
+	// times distributed with CDF t²/100.
+	r := NewRand(3)
+	var times []float64
+	for range 200 {
+		for at := range ThinnedPoissonProcess(r, func(t float64) float64 { return 2 * t }, 20, 10) {
+			times = append(times, at)
+		}
+	}
+	if mean := float64(len(times)) / 200; math.Abs(mean-100) > 3 {
+		t.Errorf("mean count %v, want ≈ 100", mean)
+	}
+	cdf := func(x float64) float64 { return math.Min(1, math.Max(0, x*x/100)) }
+	if stat := ksStatistic(times, cdf); stat > ksCritical(len(times)) {
+		t.Errorf("arrival-time KS statistic %v", stat)
+	}
+}
+
+func TestGaussianWalk(t *testing.T) {
+	const paths, steps = 20000, 50
+	r := NewRand(4)
+	var s running
+	for range paths {
+		i := 0
+		for x := range GaussianWalk(r, 1, 0.2, 0.5) {
+			if i == steps {
+				s.add(x)
+				break
+			}
+			i++
+		}
+	}
+	// After n steps the position is N(x0 + n·mu, n·sigma²).
+	if mean, variance := s.meanVar(); math.Abs(mean-11) > 0.125 || math.Abs(variance-12.5) > 0.625 {
+		t.Errorf("position after %d steps: mean %v, variance %v, want 11, 12.5", steps, mean, variance)
+	}
+}
+
+func TestGeometricBrownianMotion(t *testing.T) {
+	const paths, steps, dt = 20000, 100, 0.01
+	const s0, mu, sigma = 50.0, 0.08, 0.3
+	r := NewRand(5)
+	var s running
+	for range paths {
+		s.add(math.Log(nth(GeometricBrownianMotion(r, s0, mu, sigma, dt), steps)))
+	}
+	// ln S_T ~ N(ln s0 + (mu - sigma²/2)T, sigma²T) with T = 1.
+	mean, variance := s.meanVar()
+	if want := math.Log(s0) + mu - 0.5*sigma*sigma; math.Abs(mean-want) > 0.01 {
+		t.Errorf("E[ln S_1] = %v, want %v", mean, want)
+	}
+	if math.Abs(variance-sigma*sigma) > 0.005 {
+		t.Errorf("Var[ln S_1] = %v, want %v", variance, sigma*sigma)
+	}
+	var times []float64
+	for at := range GeometricBrownianMotion(r, s0, mu, sigma, 0.25) {
+		times = append(times, at)
+		if len(times) == 3 {
+			break
+		}
+	}
+	if !slices.Equal(times, []float64{0, 0.25, 0.5}) {
+		t.Errorf("grid = %v", times)
+	}
+}
+
+func TestOrnsteinUhlenbeck(t *testing.T) {
+	const theta, mu, sigma, dt = 2.0, -1.0, 0.8, 0.05
+	r := NewRand(6)
+	var s running
+	i := 0
+	for _, x := range OrnsteinUhlenbeck(r, 10, theta, mu, sigma, dt) {
+		// Skip the transient from x0 = 10, then sample the stationary law
+		// N(mu, sigma²/(2·theta)).
+		if i > 200 {
+			s.add(x)
+		}
+		if i++; i == 200000 {
+			break
+		}
+	}
+	mean, variance := s.meanVar()
+	if math.Abs(mean-mu) > 0.02 || math.Abs(variance-sigma*sigma/(2*theta)) > 0.01 {
+		t.Errorf("stationary mean %v variance %v, want %v %v", mean, variance, mu, sigma*sigma/(2*theta))
+	}
+}
+
+func TestMarkovChain(t *testing.T) {
+	// The stationary distribution of [[0.9, 0.1], [0.5, 0.5]] is (5/6, 1/6).
+	m, err := NewMarkovChain([][]float64{{0.9, 0.1}, {5, 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := [2]int{}
+	n := 0
+	for s := range m.Walk(NewRand(7), 1) {
+		counts[s]++
+		if n++; n == 300000 {
+			break
+		}
+	}
+	if got := float64(counts[0]) / float64(n); math.Abs(got-5.0/6) > 0.01 {
+		t.Errorf("time in state 0 = %v, want ≈ %v", got, 5.0/6)
+	}
+	absorbing, _ := NewMarkovChain([][]float64{{1, 0}, {0, 1}})
+	if s := absorbing.Step(nil, 1); s != 1 || absorbing.States() != 2 {
+		t.Errorf("absorbing chain stepped to %d", s)
+	}
+	for range m.Walk(nil, 2) {
+		t.Fatal("an out-of-range start should yield nothing")
+	}
+	for _, s := range []int{-1, 2} {
+		if next := m.Step(nil, s); next != -1 {
+			t.Errorf("Step from out-of-range state %d = %d, want -1", s, next)
+		}
+	}
+	bad := map[string][][]float64{
+		"empty":      nil,
+		"not square": {{1, 0}},
+		"zero row":   {{1, 0}, {0, 0}},
+	}
+	for name, p := range bad {
+		if _, err := NewMarkovChain(p); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// running accumulates a mean and variance for the process tests.
+type running struct{ n, sum, sumSq float64 }
+
+func (s *running) add(x float64) {
+	s.n++
+	s.sum += x
+	s.sumSq += x * x
+}
+
+func (s *running) meanVar() (float64, float64) {
+	mean := s.sum / s.n
+	return mean, (s.sumSq - s.n*mean*mean) / (s.n - 1)
+}
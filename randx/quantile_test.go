@@ -0,0 +1,105 @@
+package randx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuantile_InvertsContinuousCDF(t *testing.T) {
+	dists := map[string]interface {
+		Dist
+		Quantiler
+	}{
+		"normal":    NormalDist{Mu: 3, Sigma: 2},
+		"exp":       ExpDist{Lambda: 0.25},
+		"chi2":      Chi2Dist{K: 3},
+		"chi2 k<2":  Chi2Dist{K: 0.5},
+		"chi2 wide": Chi2Dist{K: 500},
+	}
+	for name, d := range dists {
+		for _, p := range []float64{1e-8, 0.01, 0.05, 0.5, 0.95, 0.99, 1 - 1e-8} {
+			x := d.Quantile(p)
+			if got := d.CDF(x); math.Abs(got-p) > 1e-9 {
+				t.Errorf("%s: CDF(Quantile(%v)) = %v", name, p, got)
+			}
+		}
+	}
+}
+
+func TestQuantile_KnownValues(t *testing.T) {
+	cases := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"z 0.975", NormalDist{Mu: 0, Sigma: 1}.Quantile(0.975), 1.959963984540054},
+		{"chi2(1) 0.95", Chi2Dist{K: 1}.Quantile(0.95), 3.841458820694124},
+		{"chi2(10) 0.99", Chi2Dist{K: 10}.Quantile(0.99), 23.209251158954356},
+		{"exp median", ExpDist{Lambda: 2}.Quantile(0.5), math.Ln2 / 2},
+	}
+	for _, c := range cases {
+		if math.Abs(c.got-c.want) > 1e-9 {
+			t.Errorf("%s: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestQuantile_Discrete(t *testing.T) {
+	dists := map[string]interface {
+		Dist
+		Quantiler
+	}{
+		"poisson":  PoissonDist{Lambda: 4.5},
+		"binomial": BinomDist{N: 20, P: 0.3},
+	}
+	for name, d := range dists {
+		for _, p := range []float64{0.001, 0.05, 0.5, 0.95, 0.999} {
+			k := d.Quantile(p)
+			if d.CDF(k) < p || d.CDF(k-1) >= p {
+				t.Errorf("%s: Quantile(%v) = %v is not the smallest k with CDF(k) >= p", name, p, k)
+			}
+		}
+	}
+	if got := (PoissonDist{Lambda: 3}).Quantile(1); !math.IsInf(got, 1) {
+		t.Errorf("poisson: Quantile(1) = %v, want +Inf", got)
+	}
+	if got := (BinomDist{N: 7, P: 0.5}).Quantile(1); got != 7 {
+		t.Errorf("binomial: Quantile(1) = %v, want 7", got)
+	}
+}
+
+// TestPoisson_CDFMatchesPMFSum guards the CDF against returning the upper
+// tail P(X > k) = P(k+1, λ), as it did before Quantile was added.
+func TestPoisson_CDFMatchesPMFSum(t *testing.T) {
+	for _, lambda := range []float64{0.3, 3, 12.5} {
+		d := PoissonDist{Lambda: lambda}
+		if got, want := d.CDF(0), math.Exp(-lambda); math.Abs(got-want) > 1e-12 {
+			t.Errorf("λ = %v: CDF(0) = %v, want e^-λ = %v", lambda, got, want)
+		}
+		sum := 0.0
+		for k := range 40 {
+			sum += d.PDF(float64(k))
+			if got := d.CDF(float64(k)); math.Abs(got-sum) > 1e-12 {
+				t.Errorf("λ = %v: CDF(%d) = %v, want Σ PMF = %v", lambda, k, got, sum)
+			}
+		}
+	}
+}
+
+func TestBinomQuantile_Edges(t *testing.T) {
+	if got := (BinomDist{N: 1000000, P: 0.5}).Quantile(1); got != 1000000 {
+		t.Errorf("Quantile(1) = %v, want N", got)
+	}
+	for _, c := range []struct {
+		p, q, want float64
+	}{
+		{0, 0.5, 0}, {0, 1, 0}, {1, 0.5, 12}, {1, 1e-9, 12},
+	} {
+		if got := (BinomDist{N: 12, P: c.p}).Quantile(c.q); got != c.want {
+			t.Errorf("P=%v: Quantile(%v) = %v, want %v", c.p, c.q, got, c.want)
+		}
+	}
+	if got := (BinomDist{N: 1000000, P: 1e-9}).Quantile(0.999999); got != 1 {
+		t.Errorf("tiny P: Quantile = %v, want 1", got)
+	}
+}
@@ -0,0 +1,185 @@
+package randx
+
+import (
+	"errors"
+	"math/rand/v2"
+)
+
+// FromUniform maps u in (0, 1) to a sample of d through its quantile
+// function, so that quasi-random or externally generated uniforms drive any
+// distribution. Distributions without a Quantile method are inverted
+// numerically from their CDF. u = 0 and u = 1 map to the ends of the support,
+// which may be infinite.
+func FromUniform(d Dist, u float64) float64 {
+	return quantileOf(d, u)
+}
+
+// Halton generates the Halton low-discrepancy sequence: coordinate j of
+// point n is the radical inverse of n in the j-th prime base. It starts at
+// n = 1, skipping the origin. Halton is not safe for concurrent use.
+type Halton struct {
+	bases []int
+	n     uint64
+}
+
+// NewHalton returns a Halton sequence in dim dimensions. Correlation between
+// coordinates with large neighbouring prime bases grows with the dimension
+// and shows from about ten dimensions, so prefer Sobol there, up to
+// SobolMaxDim; beyond that, LatinHypercube keeps every coordinate stratified.
+func NewHalton(dim int) (*Halton, error) {
+	if dim < 1 {
+		return nil, errors.New("randx: halton: dimension must be positive")
+	}
+	bases := make([]int, 0, dim)
+	for p := 2; len(bases) < dim; p++ {
+		prime := true
+		for _, q := range bases {
+			if q*q > p {
+				break
+			}
+			if p%q == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			bases = append(bases, p)
+		}
+	}
+	return &Halton{bases: bases}, nil
+}
+
+func (h *Halton) Dim() int {
+	return len(h.bases)
+}
+
+// Next returns the next point of the sequence.
+func (h *Halton) Next() []float64 {
+	h.n++
+	x := make([]float64, len(h.bases))
+	for j, b := range h.bases {
+		x[j] = radicalInverse(h.n, b)
+	}
+	return x
+}
+
+// Reset restarts the sequence.
+func (h *Halton) Reset() {
+	h.n = 0
+}
+
+// radicalInverse mirrors the base-b digits of n about the radix point.
+func radicalInverse(n uint64, b int) float64 {
+	base := uint64(b)
+	inv := 1 / float64(b)
+	f, x := inv, 0.0
+	for n > 0 {
+		x += float64(n%base) * f
+		n /= base
+		f *= inv
+	}
+	return x
+}
+
+// sobolBits is the precision of Sobol coordinates, which also bounds the
+// sequence to 2^sobolBits points.
+const sobolBits = 32
+
+// Sobol generates the Sobol low-discrepancy sequence with the Joe–Kuo
+// direction numbers, stepping in Gray-code order so that each point costs
+// one XOR per coordinate. It starts at the second point, skipping the origin.
+// Sobol is not safe for concurrent use.
+type Sobol struct {
+	dirs [][sobolBits]uint32
+	x    []uint32
+	n    uint32
+}
+
+// NewSobol returns a Sobol sequence in dim dimensions, for dim up to
+// SobolMaxDim.
+func NewSobol(dim int) (*Sobol, error) {
+	if dim < 1 || dim > SobolMaxDim {
+		return nil, errors.New("randx: sobol: dimension must be between 1 and SobolMaxDim")
+	}
+	s := &Sobol{dirs: make([][sobolBits]uint32, dim), x: make([]uint32, dim)}
+	for k := range sobolBits {
+		s.dirs[0][k] = 1 << (sobolBits - 1 - k)
+	}
+	for j := 1; j < dim; j++ {
+		p := sobolParams[j-1]
+		v := &s.dirs[j]
+		deg := len(p.m)
+		for k := 0; k < deg; k++ {
+			v[k] = p.m[k] << (sobolBits - 1 - k)
+		}
+		for k := deg; k < sobolBits; k++ {
+			v[k] = v[k-deg] ^ (v[k-deg] >> deg)
+			for i := 1; i < deg; i++ {
+				if (p.a>>(deg-1-i))&1 == 1 {
+					v[k] ^= v[k-i]
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+func (s *Sobol) Dim() int {
+	return len(s.x)
+}
+
+// Next returns the next point of the sequence. After 2^32 - 1 points the
+// sequence wraps around to its start.
+func (s *Sobol) Next() []float64 {
+	// Gray-code order flips the direction number of the lowest zero bit of n.
+	c := 0
+	for m := s.n; m&1 == 1; m >>= 1 {
+		c++
+	}
+	s.n++
+	if c == sobolBits {
+		s.Reset()
+		return s.Next()
+	}
+	out := make([]float64, len(s.x))
+	for j := range s.x {
+		s.x[j] ^= s.dirs[j][c]
+		out[j] = float64(s.x[j]) / (1 << sobolBits)
+	}
+	return out
+}
+
+// Reset restarts the sequence.
+func (s *Sobol) Reset() {
+	s.n = 0
+	clear(s.x)
+}
+
+// LatinHypercube returns n points in [0, 1)^dim such that, in every
+// coordinate, each of the n strata [i/n, (i+1)/n) holds exactly one point.
+// Strata are paired across coordinates by independent random permutations and
+// each point is jittered uniformly within its stratum. It returns an error if
+// n is negative or dim is not positive.
+func LatinHypercube(r *rand.Rand, n, dim int) ([][]float64, error) {
+	if n < 0 || dim <= 0 {
+		return nil, errors.New("randx: latin hypercube: n must be non-negative and dimension positive")
+	}
+	pts := make([][]float64, n)
+	for i := range pts {
+		pts[i] = make([]float64, dim)
+	}
+	perm := make([]int, n)
+	for j := range dim {
+		for i := range perm {
+			perm[i] = i
+		}
+		for i := n - 1; i > 0; i-- {
+			k := intN(r, i+1)
+			perm[i], perm[k] = perm[k], perm[i]
+		}
+		for i, p := range pts {
+			p[j] = (float64(perm[i]) + uniformPos(r)) / float64(n)
+		}
+	}
+	return pts, nil
+}
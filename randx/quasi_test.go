@@ -0,0 +1,147 @@
+package randx
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestHalton_RadicalInverse(t *testing.T) {
+	h, err := NewHalton(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]float64{
+		{1.0 / 2, 1.0 / 3, 1.0 / 5},
+		{1.0 / 4, 2.0 / 3, 2.0 / 5},
+		{3.0 / 4, 1.0 / 9, 3.0 / 5},
+		{1.0 / 8, 4.0 / 9, 4.0 / 5},
+	}
+	for i, w := range want {
+		got := h.Next()
+		for j := range w {
+			if math.Abs(got[j]-w[j]) > 1e-15 {
+				t.Fatalf("point %d = %v, want %v", i+1, got, w)
+			}
+		}
+	}
+	h.Reset()
+	if got := h.Next(); got[0] != 0.5 {
+		t.Errorf("after Reset got %v", got)
+	}
+	if h10, _ := NewHalton(10); h10.bases[9] != 29 {
+		t.Errorf("10th base = %d, want 29", h10.bases[9])
+	}
+	if _, err := NewHalton(0); err == nil {
+		t.Error("expected an error for dimension 0")
+	}
+}
+
+func TestSobol_KnownPoints(t *testing.T) {
+	s, err := NewSobol(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]float64{
+		{0.5, 0.5, 0.5},
+		{0.75, 0.25, 0.25},
+		{0.25, 0.75, 0.75},
+		{0.375, 0.375, 0.625},
+		{0.875, 0.875, 0.125},
+	}
+	for i, w := range want {
+		if got := s.Next(); !slices.Equal(got, w) {
+			t.Fatalf("point %d = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+// TestSobol_Stratified checks the (0, m)-net property of every coordinate:
+// together with the skipped origin, the first 2^m points put exactly one
+// point in each interval [k/2^m, (k+1)/2^m). It also checks that the
+// direction numbers are valid.
+func TestSobol_Stratified(t *testing.T) {
+	for j, p := range sobolParams {
+		for k, m := range p.m {
+			if m%2 == 0 || m >= 1<<(k+1) {
+				t.Fatalf("dimension %d: m[%d] = %d is not odd and below 2^%d", j+2, k, m, k+1)
+			}
+		}
+	}
+	const m = 10
+	s, err := NewSobol(SobolMaxDim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hits := make([][1 << m]int, SobolMaxDim)
+	for j := range hits {
+		hits[j][0] = 1 // the origin
+	}
+	for range 1<<m - 1 {
+		for j, x := range s.Next() {
+			hits[j][int(x*(1<<m))]++
+		}
+	}
+	for j := range hits {
+		for k, c := range hits[j] {
+			if c != 1 {
+				t.Fatalf("dimension %d: interval %d holds %d points", j+1, k, c)
+			}
+		}
+	}
+	if _, err := NewSobol(SobolMaxDim + 1); err == nil {
+		t.Error("expected an error above SobolMaxDim")
+	}
+}
+
+func TestLatinHypercube(t *testing.T) {
+	const n, dim = 50, 4
+	pts, err := LatinHypercube(NewRand(9), n, dim)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for j := range dim {
+		seen := make([]bool, n)
+		for _, p := range pts {
+			if !(p[j] > 0 && p[j] < 1) {
+				t.Fatalf("coordinate %v outside (0, 1)", p[j])
+			}
+			k := int(p[j] * n)
+			if seen[k] {
+				t.Fatalf("dimension %d: stratum %d hit twice", j, k)
+			}
+			seen[k] = true
+		}
+	}
+	for _, size := range [][2]int{{-1, 2}, {3, 0}, {3, -1}} {
+		if _, err := LatinHypercube(NewRand(9), size[0], size[1]); err == nil {
+			t.Errorf("LatinHypercube(n=%d, dim=%d) succeeded", size[0], size[1])
+		}
+	}
+	if pts, err := LatinHypercube(NewRand(9), 0, 2); err != nil || len(pts) != 0 {
+		t.Errorf("LatinHypercube(n=0) = %v, %v", pts, err)
+	}
+}
+
+// TestFromUniform_QuasiMonteCarlo compares the error of estimating E[X²]
+// for a standard normal from 4095 Sobol points against plain Monte Carlo,
+// whose error is about sqrt(2/4095) ≈ 0.022.
+func TestFromUniform_QuasiMonteCarlo(t *testing.T) {
+	d := NormalDist{Mu: 0, Sigma: 1}
+	s, _ := NewSobol(1)
+	const n = 1<<12 - 1
+	sum := 0.0
+	for range n {
+		x := FromUniform(d, s.Next()[0])
+		sum += x * x
+	}
+	if err := math.Abs(sum/n - 1); err > 5e-3 {
+		t.Errorf("QMC estimate of E[X²] = %v", sum/n)
+	}
+	if got, want := FromUniform(ExpDist{Lambda: 2}, 0.5), math.Ln2/2; math.Abs(got-want) > 1e-15 {
+		t.Errorf("FromUniform(Exp, 0.5) = %v, want %v", got, want)
+	}
+	if got := FromUniform(cdfOnly{d}, 0.975); math.Abs(got-1.959963984540054) > 1e-9 {
+		t.Errorf("numeric FromUniform = %v", got)
+	}
+}
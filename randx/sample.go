@@ -0,0 +1,44 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// Sampler is implemented by distributions that can fill a slice faster than
+// repeated calls to RandWith, typically by hoisting per-call setup out of the
+// loop.
+type Sampler interface {
+	FillWith(r *rand.Rand, dst []float64)
+}
+
+// Fill writes len(dst) samples of d into dst using the package-level generator.
+func Fill(d Dist, dst []float64) {
+	FillWith(d, nil, dst)
+}
+
+// FillWith writes len(dst) samples of d into dst using r. Distributions that
+// implement Sampler use their batched path; any other Dist falls back to
+// RandWith.
+func FillWith(d Dist, r *rand.Rand, dst []float64) {
+	if s, ok := d.(Sampler); ok {
+		s.FillWith(r, dst)
+		return
+	}
+	for i := range dst {
+		dst[i] = d.RandWith(r)
+	}
+}
+
+// Sample returns n samples of d.
+func Sample(d Dist, n int) []float64 {
+	dst := make([]float64, n)
+	Fill(d, dst)
+	return dst
+}
+
+func fillNaN(dst []float64) {
+	for i := range dst {
+		dst[i] = math.NaN()
+	}
+}
@@ -0,0 +1,93 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestFill_MatchesMoments(t *testing.T) {
+	dists := map[string]interface {
+		Dist
+		Moments
+	}{
+		"normal":       NormalDist{Mu: 2, Sigma: 3},
+		"exp":          ExpDist{Lambda: 0.5},
+		"poisson":      PoissonDist{Lambda: 7},
+		"poisson ptrs": PoissonDist{Lambda: 120},
+		"binomial":     BinomDist{N: 500, P: 0.2},
+		"chi2":         Chi2Dist{K: 4},
+		"chi2 k<2":     Chi2Dist{K: 0.8},
+		"uniform":      UniformDist{Min: -1, Max: 3},
+	}
+	const n = 200000
+	r := NewRand(7)
+	for name, d := range dists {
+		xs := make([]float64, n)
+		FillWith(d, r, xs)
+		mean, m2 := 0.0, 0.0
+		for _, x := range xs {
+			mean += x
+		}
+		mean /= n
+		for _, x := range xs {
+			m2 += (x - mean) * (x - mean)
+		}
+		variance := m2 / (n - 1)
+		// Six standard errors keeps the test deterministic in practice.
+		if se := math.Sqrt(d.Variance() / n); math.Abs(mean-d.Mean()) > 6*se {
+			t.Errorf("%s: sample mean %v, want %v ± %v", name, mean, d.Mean(), 6*se)
+		}
+		if rel := math.Abs(variance/d.Variance() - 1); rel > 0.05 {
+			t.Errorf("%s: sample variance %v, want %v", name, variance, d.Variance())
+		}
+	}
+}
+
+func TestFill_GenericFallback(t *testing.T) {
+	xs := Sample(plainDist{}, 5)
+	for _, x := range xs {
+		if x != 1 {
+			t.Fatalf("expected fallback to RandWith, got %v", xs)
+		}
+	}
+}
+
+// plainDist implements Dist without Sampler.
+type plainDist struct{}
+
+func (plainDist) Rand() float64               { return 1 }
+func (plainDist) RandWith(*rand.Rand) float64 { return 1 }
+func (plainDist) PDF(float64) float64         { return 0 }
+func (plainDist) CDF(float64) float64         { return 0 }
+
+const benchN = 1 << 12
+
+func benchRand(b *testing.B, d Dist) {
+	b.ReportAllocs()
+	dst := make([]float64, benchN)
+	r := NewRand(1)
+	for b.Loop() {
+		for i := range dst {
+			dst[i] = d.RandWith(r)
+		}
+	}
+}
+
+func benchFill(b *testing.B, d Dist) {
+	b.ReportAllocs()
+	dst := make([]float64, benchN)
+	r := NewRand(1)
+	for b.Loop() {
+		FillWith(d, r, dst)
+	}
+}
+
+func BenchmarkNormal_Rand(b *testing.B)      { benchRand(b, NormalDist{Mu: 0, Sigma: 1}) }
+func BenchmarkNormal_Fill(b *testing.B)      { benchFill(b, NormalDist{Mu: 0, Sigma: 1}) }
+func BenchmarkPoissonPTRS_Rand(b *testing.B) { benchRand(b, PoissonDist{Lambda: 200}) }
+func BenchmarkPoissonPTRS_Fill(b *testing.B) { benchFill(b, PoissonDist{Lambda: 200}) }
+func BenchmarkBinomial_Rand(b *testing.B)    { benchRand(b, BinomDist{N: 1000, P: 0.3}) }
+func BenchmarkBinomial_Fill(b *testing.B)    { benchFill(b, BinomDist{N: 1000, P: 0.3}) }
+func BenchmarkChi2_Rand(b *testing.B)        { benchRand(b, Chi2Dist{K: 5}) }
+func BenchmarkChi2_Fill(b *testing.B)        { benchFill(b, Chi2Dist{K: 5}) }
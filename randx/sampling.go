@@ -0,0 +1,158 @@
+package randx
+
+import (
+	"container/heap"
+	"iter"
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// The helpers below draw from r, or from the package-level generator when r
+// is nil. The reservoir samplers consume any iter.Seq in a single pass, so
+// they can sample from a collections.Iterable's Iter() without materializing
+// it.
+
+// Shuffle permutes xs in place uniformly at random (Fisher–Yates).
+func Shuffle[T any](r *rand.Rand, xs []T) {
+	for i := len(xs) - 1; i > 0; i-- {
+		j := intN(r, i+1)
+		xs[i], xs[j] = xs[j], xs[i]
+	}
+}
+
+// Choice returns a uniformly chosen element of xs, or false if xs is empty.
+func Choice[T any](r *rand.Rand, xs []T) (T, bool) {
+	if len(xs) == 0 {
+		var zero T
+		return zero, false
+	}
+	return xs[intN(r, len(xs))], true
+}
+
+// SampleWithoutReplacement returns the elements of xs at k distinct
+// positions chosen uniformly, in random order; k is clamped to [0, len(xs)]. xs is not
+// modified. It runs a partial Fisher–Yates shuffle, over a copy when k is a
+// sizeable fraction of xs and over a sparse index map otherwise, so the cost
+// is O(k) rather than O(len(xs)) for small samples.
+func SampleWithoutReplacement[T any](r *rand.Rand, xs []T, k int) []T {
+	n := len(xs)
+	k = min(max(k, 0), n)
+	if 4*k >= n {
+		c := slices.Clone(xs)
+		for i := range k {
+			j := i + intN(r, n-i)
+			c[i], c[j] = c[j], c[i]
+		}
+		return c[:k:k]
+	}
+	// moved[i] is the position currently shuffled into slot i.
+	moved := make(map[int]int, 2*k)
+	at := func(i int) int {
+		if p, ok := moved[i]; ok {
+			return p
+		}
+		return i
+	}
+	out := make([]T, k)
+	for i := range k {
+		j := i + intN(r, n-i)
+		pj := at(j)
+		moved[j] = at(i)
+		out[i] = xs[pj]
+	}
+	return out
+}
+
+// Reservoir returns a uniform sample of k items from seq, or every item if
+// seq yields fewer than k, using Li's Algorithm L. Instead of drawing a
+// random number per item it jumps over a geometrically distributed number of
+// items between replacements, so it draws O(k·log(n/k)) numbers for a
+// stream of n items. The sample is in no particular order.
+func Reservoir[T any](r *rand.Rand, seq iter.Seq[T], k int) []T {
+	if k <= 0 {
+		return nil
+	}
+	out := make([]T, 0, k)
+	w := math.Exp(math.Log(uniformPos(r)) / float64(k))
+	skip := 0
+	for x := range seq {
+		if len(out) < k {
+			out = append(out, x)
+			if len(out) == k {
+				skip = reservoirSkip(r, w)
+			}
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		out[intN(r, k)] = x
+		w *= math.Exp(math.Log(uniformPos(r)) / float64(k))
+		skip = reservoirSkip(r, w)
+	}
+	return out
+}
+
+// reservoirSkip draws the number of items Algorithm L passes over before the
+// next replacement. Skips too large for an int saturate at math.MaxInt, which
+// no stream reaches.
+func reservoirSkip(r *rand.Rand, w float64) int {
+	s := math.Floor(math.Log(uniformPos(r)) / math.Log1p(-w))
+	if s >= math.MaxInt || math.IsNaN(s) {
+		return math.MaxInt
+	}
+	return int(s)
+}
+
+// WeightedReservoir returns k items of seq sampled without replacement with
+// probability proportional to weight, in one pass, by Efraimidis and
+// Spirakis' A-ES: each item gets the key u^(1/w) and the k largest keys win.
+// Keys are kept as ln(u)/w so tiny weights do not underflow. Items with a
+// non-positive or NaN weight are never chosen. The result is ordered by
+// decreasing key, which is the order of successive weighted draws without
+// replacement.
+func WeightedReservoir[T any](r *rand.Rand, seq iter.Seq[T], weight func(T) float64, k int) []T {
+	if k <= 0 {
+		return nil
+	}
+	h := make(keyedHeap[T], 0, k)
+	for x := range seq {
+		w := weight(x)
+		if !(w > 0) {
+			continue
+		}
+		key := math.Log(uniformPos(r)) / w
+		if len(h) < k {
+			heap.Push(&h, keyed[T]{key, x})
+		} else if key > h[0].key {
+			h[0] = keyed[T]{key, x}
+			heap.Fix(&h, 0)
+		}
+	}
+	out := make([]T, len(h))
+	for i := len(h) - 1; i >= 0; i-- {
+		out[i] = heap.Pop(&h).(keyed[T]).item
+	}
+	return out
+}
+
+type keyed[T any] struct {
+	key  float64
+	item T
+}
+
+// keyedHeap is a min-heap on key, so the weakest retained item is at the top.
+type keyedHeap[T any] []keyed[T]
+
+func (h keyedHeap[T]) Len() int           { return len(h) }
+func (h keyedHeap[T]) Less(i, j int) bool { return h[i].key < h[j].key }
+func (h keyedHeap[T]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *keyedHeap[T]) Push(x any)        { *h = append(*h, x.(keyed[T])) }
+func (h *keyedHeap[T]) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,178 @@
+package randx
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/miguelm-revel/revelTools/collections"
+)
+
+// inclusionWithin checks that every count is within 5 standard deviations of
+// trials·p.
+func inclusionWithin(t *testing.T, name string, counts []int, trials int, p []float64) {
+	t.Helper()
+	for i, c := range counts {
+		want := float64(trials) * p[i]
+		if sd := math.Sqrt(want * (1 - p[i])); math.Abs(float64(c)-want) > 5*sd+1e-9 {
+			t.Errorf("%s: item %d chosen %d times, want ≈ %.0f", name, i, c, want)
+		}
+	}
+}
+
+func uniformP(n int, p float64) []float64 {
+	ps := make([]float64, n)
+	for i := range ps {
+		ps[i] = p
+	}
+	return ps
+}
+
+func TestShuffle_UniformPermutations(t *testing.T) {
+	r := NewRand(1)
+	counts := map[[3]int]int{}
+	const trials = 60000
+	for range trials {
+		xs := []int{0, 1, 2}
+		Shuffle(r, xs)
+		counts[[3]int(xs)]++
+	}
+	if len(counts) != 6 {
+		t.Fatalf("saw %d permutations, want 6", len(counts))
+	}
+	perm := make([]int, 0, 6)
+	for _, c := range counts {
+		perm = append(perm, c)
+	}
+	inclusionWithin(t, "shuffle", perm, trials, uniformP(6, 1.0/6))
+}
+
+func TestChoice(t *testing.T) {
+	if _, ok := Choice[int](nil, nil); ok {
+		t.Error("Choice on an empty slice reported ok")
+	}
+	r := NewRand(2)
+	counts := make([]int, 4)
+	for range 40000 {
+		x, ok := Choice(r, []int{0, 1, 2, 3})
+		if !ok {
+			t.Fatal("Choice reported not ok")
+		}
+		counts[x]++
+	}
+	inclusionWithin(t, "choice", counts, 40000, uniformP(4, 0.25))
+}
+
+func TestSampleWithoutReplacement(t *testing.T) {
+	r := NewRand(3)
+	xs := make([]int, 40)
+	for i := range xs {
+		xs[i] = i
+	}
+	// k = 3 takes the sparse path and k = 30 the copying one.
+	for _, k := range []int{3, 30} {
+		counts := make([]int, len(xs))
+		const trials = 20000
+		for range trials {
+			got := SampleWithoutReplacement(r, xs, k)
+			if len(got) != k {
+				t.Fatalf("got %d items, want %d", len(got), k)
+			}
+			seen := map[int]bool{}
+			for _, x := range got {
+				if seen[x] {
+					t.Fatalf("duplicate %d in %v", x, got)
+				}
+				seen[x] = true
+				counts[x]++
+			}
+		}
+		inclusionWithin(t, "without replacement", counts, trials, uniformP(len(xs), float64(k)/float64(len(xs))))
+	}
+	if !slices.Equal(xs[:3], []int{0, 1, 2}) {
+		t.Error("input was modified")
+	}
+	if got := SampleWithoutReplacement(r, xs[:5], 9); len(got) != 5 {
+		t.Errorf("k above len(xs) gave %d items", len(got))
+	}
+}
+
+func TestReservoir_FromSet(t *testing.T) {
+	const n, k, trials = 200, 10, 20000
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+	set := collections.NewSet(items)
+	r := NewRand(4)
+	counts := make([]int, n)
+	for range trials {
+		got := Reservoir(r, set.Iter(), k)
+		if len(got) != k {
+			t.Fatalf("got %d items, want %d", len(got), k)
+		}
+		for _, x := range got {
+			counts[x]++
+		}
+	}
+	inclusionWithin(t, "reservoir", counts, trials, uniformP(n, float64(k)/n))
+
+	short := Reservoir(r, slices.Values([]string{"a", "b"}), 5)
+	slices.Sort(short)
+	if !slices.Equal(short, []string{"a", "b"}) {
+		t.Errorf("short stream gave %v", short)
+	}
+	if Reservoir(r, slices.Values(items), 0) != nil {
+		t.Error("k = 0 should give nil")
+	}
+}
+
+func TestReservoirSkip_Large(t *testing.T) {
+	// Once w is tiny the skips are geometric with mean about 1/w, far beyond
+	// the int32 range, and must not be capped there.
+	r := NewRand(9)
+	short := 0
+	for range 1000 {
+		if reservoirSkip(r, 1e-12) <= math.MaxInt32 {
+			short++
+		}
+	}
+	// P(skip <= 2³¹) ≈ 0.002.
+	if short > 20 {
+		t.Errorf("%d of 1000 skips at most MaxInt32", short)
+	}
+	if s := reservoirSkip(r, 1e-300); s != math.MaxInt {
+		t.Errorf("skip for w = 1e-300 is %d, want MaxInt", s)
+	}
+}
+
+func TestWeightedReservoir(t *testing.T) {
+	weights := []float64{1, 2, 0, 3, 4}
+	items := []int{0, 1, 2, 3, 4}
+	weight := func(i int) float64 { return weights[i] }
+	r := NewRand(5)
+	const trials = 50000
+	first := make([]int, len(items))
+	for range trials {
+		got := WeightedReservoir(r, slices.Values(items), weight, 2)
+		if len(got) != 2 || got[0] == got[1] {
+			t.Fatalf("bad sample %v", got)
+		}
+		if got[0] == 2 || got[1] == 2 {
+			t.Fatal("zero-weight item was chosen")
+		}
+		first[got[0]]++
+	}
+	// The first item follows the normalized weights.
+	inclusionWithin(t, "weighted first draw", first, trials, []float64{0.1, 0.2, 0, 0.3, 0.4})
+
+	// With equal weights it reduces to uniform sampling.
+	counts := make([]int, 10)
+	for range trials {
+		for _, x := range WeightedReservoir(r, slices.Values([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}),
+			func(int) float64 { return 1e-300 }, 3) {
+			counts[x]++
+		}
+	}
+	inclusionWithin(t, "tiny equal weights", counts, trials, uniformP(10, 0.3))
+}
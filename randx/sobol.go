@@ -0,0 +1,34 @@
+package randx
+
+// SobolMaxDim is the largest dimension supported by NewSobol.
+const SobolMaxDim = 21
+
+// sobolParams holds the primitive polynomials and initial direction numbers
+// of Joe and Kuo (2008), file new-joe-kuo-6.21201, for dimensions 2 onwards.
+// a encodes the inner coefficients of the polynomial of degree len(m), and
+// m[k] is the odd initial direction number below 2^(k+1).
+var sobolParams = [SobolMaxDim - 1]struct {
+	a uint32
+	m []uint32
+}{
+	{0, []uint32{1}},
+	{1, []uint32{1, 3}},
+	{1, []uint32{1, 3, 1}},
+	{2, []uint32{1, 1, 1}},
+	{1, []uint32{1, 1, 3, 3}},
+	{4, []uint32{1, 3, 5, 13}},
+	{2, []uint32{1, 1, 5, 5, 17}},
+	{4, []uint32{1, 1, 5, 5, 5}},
+	{7, []uint32{1, 1, 7, 11, 19}},
+	{11, []uint32{1, 1, 5, 1, 1}},
+	{13, []uint32{1, 1, 1, 3, 11}},
+	{14, []uint32{1, 3, 5, 5, 31}},
+	{1, []uint32{1, 3, 3, 9, 7, 49}},
+	{13, []uint32{1, 1, 1, 15, 21, 21}},
+	{16, []uint32{1, 3, 1, 13, 27, 49}},
+	{19, []uint32{1, 1, 1, 15, 7, 5}},
+	{22, []uint32{1, 3, 1, 15, 13, 25}},
+	{25, []uint32{1, 1, 5, 5, 19, 61}},
+	{1, []uint32{1, 3, 7, 11, 23, 15, 103}},
+	{4, []uint32{1, 3, 7, 13, 13, 15, 69}},
+}
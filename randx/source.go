@@ -0,0 +1,55 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// NewRand returns a generator seeded deterministically from seed. Passing it
+// to RandWith yields the same sample stream on every run.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+}
+
+// uniform returns a uniform variate in [0, 1) drawn from r, or from the
+// package-level generator of math/rand/v2 when r is nil.
+func uniform(r *rand.Rand) float64 {
+	if r == nil {
+		return rand.Float64()
+	}
+	return r.Float64()
+}
+
+// uniformPos is like uniform but never returns 0, so its logarithm is finite.
+func uniformPos(r *rand.Rand) float64 {
+	u := uniform(r)
+	if u == 0 {
+		u = math.SmallestNonzeroFloat64
+	}
+	return u
+}
+
+func uint64With(r *rand.Rand) uint64 {
+	if r == nil {
+		return rand.Uint64()
+	}
+	return r.Uint64()
+}
+
+// uint64N returns a uniform integer in [0, n) drawn from r, or from the
+// package-level generator when r is nil.
+func uint64N(r *rand.Rand, n uint64) uint64 {
+	if r == nil {
+		return rand.Uint64N(n)
+	}
+	return r.Uint64N(n)
+}
+
+// intN returns a uniform integer in [0, n) drawn from r, or from the
+// package-level generator when r is nil.
+func intN(r *rand.Rand, n int) int {
+	if r == nil {
+		return rand.IntN(n)
+	}
+	return r.IntN(n)
+}
@@ -0,0 +1,38 @@
+package randx
+
+import "testing"
+
+func TestRandWith_SeededStreamsMatch(t *testing.T) {
+	dists := map[string]Dist{
+		"normal":   NormalDist{Mu: 1, Sigma: 2},
+		"exp":      ExpDist{Lambda: 0.5},
+		"poisson":  PoissonDist{Lambda: 4},
+		"ptrs":     PoissonDist{Lambda: 80},
+		"binomial": BinomDist{N: 20, P: 0.3},
+		"chi2":     Chi2Dist{K: 3},
+		"chi2<1":   Chi2Dist{K: 1},
+	}
+	for name, d := range dists {
+		a, b := NewRand(42), NewRand(42)
+		for i := 0; i < 1000; i++ {
+			x, y := d.RandWith(a), d.RandWith(b)
+			if x != y {
+				t.Fatalf("%s: sample %d differs: %v != %v", name, i, x, y)
+			}
+		}
+	}
+}
+
+func TestRandWith_DifferentSeedsDiffer(t *testing.T) {
+	d := NormalDist{Mu: 0, Sigma: 1}
+	a, b := NewRand(1), NewRand(2)
+	same := 0
+	for i := 0; i < 100; i++ {
+		if d.RandWith(a) == d.RandWith(b) {
+			same++
+		}
+	}
+	if same == 100 {
+		t.Fatalf("expected different seeds to produce different streams")
+	}
+}
@@ -0,0 +1,169 @@
+package special
+
+import "math"
+
+// RegIncBeta returns the regularized incomplete beta function I_x(a, b),
+// the CDF of Beta(a, b) (Numerical Recipes, betai/betacf).
+func RegIncBeta(a, b, x float64) float64 {
+	if a <= 0 || b <= 0 || math.IsNaN(x) || x < 0 || x > 1 {
+		return math.NaN()
+	}
+	if x == 0 {
+		return 0
+	}
+	if x == 1 {
+		return 1
+	}
+
+	lbt := betaLogPrefix(a, b, x)
+	if x < (a+1)/(a+b+2) {
+		return math.Exp(lbt) * betaContFrac(a, b, x) / a
+	}
+	return 1 - math.Exp(lbt)*betaContFrac(b, a, 1-x)/b
+}
+
+// LogRegIncBeta returns ln I_x(a, b). For the upper tail use
+// I_x(a, b) = 1 - I_{1-x}(b, a).
+func LogRegIncBeta(a, b, x float64) float64 {
+	if a <= 0 || b <= 0 || math.IsNaN(x) || x < 0 || x > 1 {
+		return math.NaN()
+	}
+	if x == 0 {
+		return math.Inf(-1)
+	}
+	if x == 1 {
+		return 0
+	}
+	lbt := betaLogPrefix(a, b, x)
+	if x < (a+1)/(a+b+2) {
+		return lbt + math.Log(betaContFrac(a, b, x)/a)
+	}
+	return math.Log1p(-math.Exp(lbt) * betaContFrac(b, a, 1-x) / b)
+}
+
+// betaLogPrefix is ln(x^a (1-x)^b / B(a, b)).
+func betaLogPrefix(a, b, x float64) float64 {
+	return a*math.Log(x) + b*math.Log1p(-x) - LogBeta(a, b)
+}
+
+func betaContFrac(a, b, x float64) float64 {
+	const eps = 3e-14
+	const fpmin = 1e-300
+	// The fraction converges in O(sqrt(max(a, b))) iterations.
+	itmax := 200 + int(10*math.Sqrt(math.Max(a, b)))
+
+	qab := a + b
+	qap := a + 1
+	qam := a - 1
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < fpmin {
+		d = fpmin
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= itmax; m++ {
+		fm := float64(m)
+		m2 := 2 * fm
+
+		aa := fm * (b - fm) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < fpmin {
+			d = fpmin
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < fpmin {
+			c = fpmin
+		}
+		d = 1 / d
+		h *= d * c
+
+		aa = -(a + fm) * (qab + fm) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < fpmin {
+			d = fpmin
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < fpmin {
+			c = fpmin
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < eps {
+			break
+		}
+	}
+	return h
+}
+
+// InvRegIncBeta returns x such that I_x(a, b) = p (Numerical Recipes,
+// invbetai).
+func InvRegIncBeta(a, b, p float64) float64 {
+	switch {
+	case a <= 0 || b <= 0 || math.IsNaN(p) || p < 0 || p > 1:
+		return math.NaN()
+	case p == 0:
+		return 0
+	case p == 1:
+		return 1
+	}
+
+	const eps = 1e-10
+	a1 := a - 1
+	b1 := b - 1
+	var x float64
+
+	if a >= 1 && b >= 1 {
+		pp := p
+		if p >= 0.5 {
+			pp = 1 - p
+		}
+		t := math.Sqrt(-2 * math.Log(pp))
+		x = (2.30753+t*0.27061)/(1+t*(0.99229+t*0.04481)) - t
+		if p < 0.5 {
+			x = -x
+		}
+		al := (x*x - 3) / 6
+		h := 2 / (1/(2*a-1) + 1/(2*b-1))
+		w := x*math.Sqrt(al+h)/h - (1/(2*b-1)-1/(2*a-1))*(al+5.0/6-2/(3*h))
+		x = a / (a + b*math.Exp(2*w))
+	} else {
+		lna := math.Log(a / (a + b))
+		lnb := math.Log(b / (a + b))
+		t := math.Exp(a*lna) / a
+		u := math.Exp(b*lnb) / b
+		w := t + u
+		if p < t/w {
+			x = math.Pow(a*w*p, 1/a)
+		} else {
+			x = 1 - math.Pow(b*w*(1-p), 1/b)
+		}
+	}
+
+	afac := -LogBeta(a, b)
+	for j := range maxIter {
+		if x == 0 || x == 1 {
+			return x
+		}
+		err := RegIncBeta(a, b, x) - p
+		t := math.Exp(a1*math.Log(x) + b1*math.Log1p(-x) + afac)
+		if t == 0 || math.IsInf(t, 0) {
+			break
+		}
+		u := err / t
+		t = u / (1 - 0.5*math.Min(1, u*(a1/x-b1/(1-x))))
+		x -= t
+		if x <= 0 {
+			x = 0.5 * (x + t)
+		}
+		if x >= 1 {
+			x = 0.5 * (x + t + 1)
+		}
+		if math.Abs(t) < eps*x && j > 0 {
+			break
+		}
+	}
+	return x
+}
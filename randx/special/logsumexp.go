@@ -0,0 +1,37 @@
+package special
+
+import "math"
+
+// LogSumExp returns ln Σ exp(xs[i]) without overflow or underflow, by
+// factoring out the largest term. It returns -Inf for an empty slice.
+func LogSumExp(xs []float64) float64 {
+	m := math.Inf(-1)
+	for _, x := range xs {
+		if math.IsNaN(x) {
+			return math.NaN()
+		}
+		m = math.Max(m, x)
+	}
+	if math.IsInf(m, 0) {
+		return m
+	}
+	sum := 0.0
+	for _, x := range xs {
+		sum += math.Exp(x - m)
+	}
+	return m + math.Log(sum)
+}
+
+// LogAddExp returns ln(exp(a) + exp(b)).
+func LogAddExp(a, b float64) float64 {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return math.NaN()
+	}
+	if a < b {
+		a, b = b, a
+	}
+	if math.IsInf(a, 1) || math.IsInf(b, -1) {
+		return a
+	}
+	return a + math.Log1p(math.Exp(b-a))
+}
@@ -0,0 +1,314 @@
+package special
+
+import (
+	"math"
+	"testing"
+)
+
+const (
+	eulerGamma = 0.57721566490153286061
+	catalan    = 0.91596559417721901505
+)
+
+// near reports whether got matches want to within tol, relative to
+// max(1, |want|).
+func near(got, want, tol float64) bool {
+	if math.IsInf(want, 0) || math.IsNaN(want) {
+		return got == want || math.IsNaN(got) && math.IsNaN(want)
+	}
+	return math.Abs(got-want) <= tol*math.Max(1, math.Abs(want))
+}
+
+func harmonic(n int) float64 {
+	h := 0.0
+	for k := 1; k <= n; k++ {
+		h += 1 / float64(k)
+	}
+	return h
+}
+
+func TestLogGamma(t *testing.T) {
+	cases := []struct{ x, want float64 }{
+		{0.5, 0.5 * math.Log(math.Pi)},
+		{1, 0},
+		{2, 0},
+		{10, math.Log(362880)},
+		{-0.5, math.Log(2 * math.Sqrt(math.Pi))},
+	}
+	for _, c := range cases {
+		if got := LogGamma(c.x); !near(got, c.want, 1e-15) {
+			t.Errorf("LogGamma(%v) = %v, want %v", c.x, got, c.want)
+		}
+	}
+	if got := LogChoose(10, 3); !near(got, math.Log(120), 1e-14) {
+		t.Errorf("LogChoose(10, 3) = %v", got)
+	}
+	if !math.IsInf(LogChoose(3, 4), -1) || !math.IsInf(LogChoose(3, -1), -1) {
+		t.Error("LogChoose outside [0, n] should be -Inf")
+	}
+	if got := LogBeta(2, 3); !near(got, math.Log(1.0/12), 1e-14) {
+		t.Errorf("LogBeta(2, 3) = %v", got)
+	}
+}
+
+func TestDigamma(t *testing.T) {
+	cases := []struct{ x, want float64 }{
+		{1, -eulerGamma},
+		{2, 1 - eulerGamma},
+		{10, harmonic(9) - eulerGamma},
+		{100, harmonic(99) - eulerGamma},
+		{0.5, -eulerGamma - 2*math.Ln2},
+		{2.5, -eulerGamma - 2*math.Ln2 + 2 + 2.0/3},
+		{1.0 / 3, -eulerGamma - math.Pi/(2*math.Sqrt(3)) - 1.5*math.Log(3)},
+		{0.25, -eulerGamma - math.Pi/2 - 3*math.Ln2},
+		{-0.5, -eulerGamma - 2*math.Ln2 + 2},
+		{1e-8, -1e8 - eulerGamma},
+		{10, 2.251752589066721},
+		{-0.5, 0.03648997397857652},
+	}
+	for _, c := range cases {
+		if got := Digamma(c.x); !near(got, c.want, 1e-14) {
+			t.Errorf("Digamma(%v) = %v, want %v", c.x, got, c.want)
+		}
+	}
+	for _, x := range []float64{0, -3, math.NaN(), math.Inf(-1)} {
+		if got := Digamma(x); !math.IsNaN(got) {
+			t.Errorf("Digamma(%v) = %v, want NaN", x, got)
+		}
+	}
+	for _, x := range []float64{1e-3, 0.2, 1, 7.5, 300} {
+		if got := InvDigamma(Digamma(x)); !near(got, x, 1e-12) {
+			t.Errorf("InvDigamma(Digamma(%v)) = %v", x, got)
+		}
+	}
+}
+
+func TestTrigamma(t *testing.T) {
+	pi2 := math.Pi * math.Pi
+	cases := []struct{ x, want float64 }{
+		{1, pi2 / 6},
+		{2, pi2/6 - 1},
+		{5, pi2/6 - 1 - 1.0/4 - 1.0/9 - 1.0/16},
+		{0.5, pi2 / 2},
+		{0.25, pi2 + 8*catalan},
+		{-0.5, pi2/2 + 4},
+	}
+	for _, c := range cases {
+		if got := Trigamma(c.x); !near(got, c.want, 1e-13) {
+			t.Errorf("Trigamma(%v) = %v, want %v", c.x, got, c.want)
+		}
+	}
+	if got := Trigamma(-2); !math.IsNaN(got) {
+		t.Errorf("Trigamma(-2) = %v, want NaN", got)
+	}
+}
+
+// poissonTail returns P(n, x) = 1 - e^-x Σ_{k<n} x^k/k! for integer n.
+func poissonTail(n int, x float64) float64 {
+	sum, term := 0.0, 1.0
+	for k := range n {
+		if k > 0 {
+			term *= x / float64(k)
+		}
+		sum += term
+	}
+	return 1 - math.Exp(-x)*sum
+}
+
+func TestRegLowerGamma(t *testing.T) {
+	cases := []struct{ a, x, want float64 }{
+		{1, 0.1, -math.Expm1(-0.1)},
+		{1, 5, -math.Expm1(-5)},
+		{0.5, 0.3, math.Erf(math.Sqrt(0.3))},
+		{0.5, 4, math.Erf(2)},
+		{3, 0.5, poissonTail(3, 0.5)},
+		{3, 7, poissonTail(3, 7)},
+		{30, 25, poissonTail(30, 25)},
+		{30, 40, poissonTail(30, 40)},
+	}
+	for _, c := range cases {
+		p := RegLowerGamma(c.a, c.x)
+		if !near(p, c.want, 1e-13) {
+			t.Errorf("P(%v, %v) = %v, want %v", c.a, c.x, p, c.want)
+		}
+		if q := RegUpperGamma(c.a, c.x); !near(p+q, 1, 1e-14) {
+			t.Errorf("P + Q at (%v, %v) = %v", c.a, c.x, p+q)
+		}
+		if lp := LogRegLowerGamma(c.a, c.x); !near(lp, math.Log(c.want), 1e-12) {
+			t.Errorf("ln P(%v, %v) = %v, want %v", c.a, c.x, lp, math.Log(c.want))
+		}
+	}
+	// Tails where P or Q underflow.
+	if got := LogRegUpperGamma(1, 1000); !near(got, -1000, 1e-14) {
+		t.Errorf("ln Q(1, 1000) = %v, want -1000", got)
+	}
+	if got := LogRegLowerGamma(1, 1e-300); !near(got, -300*math.Ln10, 1e-14) {
+		t.Errorf("ln P(1, 1e-300) = %v", got)
+	}
+	if got := RegUpperGamma(1, 50); !near(got/math.Exp(-50), 1, 1e-13) {
+		t.Errorf("Q(1, 50) = %v, want e^-50", got)
+	}
+	inf := math.Inf(1)
+	if RegLowerGamma(2.5, inf) != 1 || LogRegLowerGamma(2.5, inf) != 0 || RegUpperGamma(2.5, inf) != 0 || !math.IsInf(LogRegUpperGamma(2.5, inf), -1) {
+		t.Errorf("at x = +Inf: P %v, ln P %v, Q %v, ln Q %v", RegLowerGamma(2.5, inf), LogRegLowerGamma(2.5, inf), RegUpperGamma(2.5, inf), LogRegUpperGamma(2.5, inf))
+	}
+	if !math.IsNaN(RegLowerGamma(0, 1)) || !math.IsNaN(RegLowerGamma(1, -1)) {
+		t.Error("invalid arguments should give NaN")
+	}
+}
+
+func TestRegLowerGamma_LargeShape(t *testing.T) {
+	// Reference P and Q from the Poisson sum evaluated to 40 digits.
+	cases := []struct{ a, x, p, q float64 }{
+		{1e4, 1e4, 0.50132980833995522, 0.49867019166004478},
+		{1e4, 9800, 0.022207543813969693, 0.97779245618603028},
+		{1e4, 10300, 0.99852950510361427, 0.0014704948963856813},
+		{5e4, 5e4, 0.50059470810479334, 0.49940529189520672},
+		{1e5, 1e5, 0.50042052211036514, 0.4995794778896348},
+		{1e6, 1e6, 0.50013298076087254, 0.49986701923912741},
+		{1e6, 998000, 0.022696114006736802, 0.97730388599326323},
+	}
+	for _, c := range cases {
+		if got := RegLowerGamma(c.a, c.x); !near(got, c.p, 1e-11) {
+			t.Errorf("P(%v, %v) = %v, want %v", c.a, c.x, got, c.p)
+		}
+		if got := RegUpperGamma(c.a, c.x); !near(got, c.q, 1e-11) {
+			t.Errorf("Q(%v, %v) = %v, want %v", c.a, c.x, got, c.q)
+		}
+	}
+	if got := InvRegLowerGamma(5e4, 0.5); !near(got, 49999.666667, 1e-10) {
+		t.Errorf("InvRegLowerGamma(5e4, 0.5) = %v", got)
+	}
+}
+
+func TestInvRegLowerGamma(t *testing.T) {
+	for _, a := range []float64{0.1, 0.5, 1, 2.5, 30, 500} {
+		for _, p := range []float64{1e-10, 0.01, 0.3, 0.5, 0.9, 0.999999} {
+			x := InvRegLowerGamma(a, p)
+			if got := RegLowerGamma(a, x); !near(got, p, 1e-9*math.Max(1, 1/p)*p) {
+				t.Errorf("P(%v, InvRegLowerGamma(%v, %v) = %v) = %v", a, a, p, x, got)
+			}
+		}
+	}
+	if InvRegLowerGamma(2, 0) != 0 || !math.IsInf(InvRegLowerGamma(2, 1), 1) {
+		t.Error("wrong endpoints")
+	}
+}
+
+// binomialTail returns I_x(a, b) for integer a, b as the binomial sum
+// Σ_{j=a}^{a+b-1} C(a+b-1, j) x^j (1-x)^(a+b-1-j).
+func binomialTail(a, b int, x float64) float64 {
+	n := a + b - 1
+	sum := 0.0
+	for j := a; j <= n; j++ {
+		sum += math.Exp(LogChoose(n, j)) * math.Pow(x, float64(j)) * math.Pow(1-x, float64(n-j))
+	}
+	return sum
+}
+
+func TestRegIncBeta(t *testing.T) {
+	cases := []struct{ a, b, x, want float64 }{
+		{2.5, 1, 0.3, math.Pow(0.3, 2.5)},
+		{1, 4, 0.2, 1 - math.Pow(0.8, 4)},
+		{0.5, 0.5, 0.1, 2 / math.Pi * math.Asin(math.Sqrt(0.1))},
+		{0.5, 0.5, 0.9, 2 / math.Pi * math.Asin(math.Sqrt(0.9))},
+		{2, 3, 0.4, binomialTail(2, 3, 0.4)},
+		{7, 12, 0.35, binomialTail(7, 12, 0.35)},
+		{20, 5, 0.9, binomialTail(20, 5, 0.9)},
+		{50, 50, 0.5, 0.5},
+		{100, 100, 0.5, 0.5},
+	}
+	// The prefix x^a (1-x)^b / B(a, b) is formed in log space from terms of
+	// size ~a+b, which costs a few digits for the larger parameters.
+	for _, c := range cases {
+		got := RegIncBeta(c.a, c.b, c.x)
+		if !near(got, c.want, 5e-13) {
+			t.Errorf("I_%v(%v, %v) = %v, want %v", c.x, c.a, c.b, got, c.want)
+		}
+		if sym := 1 - RegIncBeta(c.b, c.a, 1-c.x); !near(sym, c.want, 5e-13) {
+			t.Errorf("1 - I_%v(%v, %v) = %v, want %v", 1-c.x, c.b, c.a, sym, c.want)
+		}
+		if lg := LogRegIncBeta(c.a, c.b, c.x); !near(lg, math.Log(c.want), 1e-12) {
+			t.Errorf("ln I_%v(%v, %v) = %v, want %v", c.x, c.a, c.b, lg, math.Log(c.want))
+		}
+	}
+	// I_x(a, 1) = x^a keeps its digits in log space long after it underflows.
+	if got := LogRegIncBeta(200, 1, 1e-3); !near(got, 200*math.Log(1e-3), 1e-13) {
+		t.Errorf("ln I_0.001(200, 1) = %v", got)
+	}
+	if !math.IsNaN(RegIncBeta(1, 0, 0.5)) || !math.IsNaN(RegIncBeta(1, 1, 1.5)) {
+		t.Error("invalid arguments should give NaN")
+	}
+}
+
+func TestInvRegIncBeta(t *testing.T) {
+	for _, ab := range [][2]float64{{0.5, 0.5}, {0.2, 3}, {1, 1}, {2, 5}, {30, 8}, {300, 300}} {
+		for _, p := range []float64{1e-8, 0.05, 0.5, 0.77, 0.9999} {
+			x := InvRegIncBeta(ab[0], ab[1], p)
+			if got := RegIncBeta(ab[0], ab[1], x); math.Abs(got-p) > 1e-9*p {
+				t.Errorf("I(%v) at InvRegIncBeta(%v) = %v, want %v", ab, p, got, p)
+			}
+		}
+	}
+}
+
+func TestErfInv(t *testing.T) {
+	if got := ErfInv(0.5); !near(got, 0.4769362762044699, 1e-15) {
+		t.Errorf("ErfInv(0.5) = %v", got)
+	}
+	for _, x := range []float64{-0.999999, -0.9, -0.3, 0, 1e-12, 0.2, 0.5000001, 0.8, 0.99} {
+		if got := math.Erf(ErfInv(x)); !near(got, x, 1e-15) {
+			t.Errorf("erf(ErfInv(%v)) = %v", x, got)
+		}
+	}
+	for _, y := range []float64{1e-300, 1e-100, 1e-20, 1e-5, 0.3, 1, 1.7} {
+		if got := math.Erfc(ErfcInv(y)); math.Abs(got-y) > 1e-13*y {
+			t.Errorf("erfc(ErfcInv(%v)) = %v", y, got)
+		}
+	}
+	if !math.IsInf(ErfInv(1), 1) || !math.IsInf(ErfInv(-1), -1) || !math.IsNaN(ErfInv(1.5)) {
+		t.Error("wrong endpoints")
+	}
+	if got := NormQuantile(0.975); !near(got, 1.959963984540054, 1e-15) {
+		t.Errorf("NormQuantile(0.975) = %v", got)
+	}
+}
+
+func TestLogNormCDF(t *testing.T) {
+	for _, z := range []float64{-5, 0, 3} {
+		if got, want := LogNormCDF(z), math.Log(0.5*math.Erfc(-z/math.Sqrt2)); !near(got, want, 1e-15) {
+			t.Errorf("LogNormCDF(%v) = %v, want %v", z, got, want)
+		}
+	}
+	// The switch to the asymptotic series at z = -37 is continuous.
+	if lo, hi := LogNormCDF(-37-1e-9), LogNormCDF(-37+1e-9); math.Abs(lo-hi) > 1e-6 {
+		t.Errorf("jump at -37: %v vs %v", lo, hi)
+	}
+}
+
+func TestLogSumExp(t *testing.T) {
+	inf := math.Inf(1)
+	cases := []struct {
+		xs   []float64
+		want float64
+	}{
+		{nil, -inf},
+		{[]float64{0, 0}, math.Ln2},
+		{[]float64{1000, 1000, 1000}, 1000 + math.Log(3)},
+		{[]float64{-1000, -1000 + math.Ln2}, -1000 + math.Log(3)},
+		{[]float64{-inf, 2}, 2},
+		{[]float64{-inf, -inf}, -inf},
+		{[]float64{inf, 3}, inf},
+		{[]float64{1, math.NaN()}, math.NaN()},
+	}
+	for _, c := range cases {
+		if got := LogSumExp(c.xs); !near(got, c.want, 1e-15) {
+			t.Errorf("LogSumExp(%v) = %v, want %v", c.xs, got, c.want)
+		}
+		if len(c.xs) == 2 {
+			if got := LogAddExp(c.xs[0], c.xs[1]); !near(got, c.want, 1e-15) {
+				t.Errorf("LogAddExp(%v) = %v, want %v", c.xs, got, c.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// P2Quantile estimates a single quantile of a stream in O(1) memory with the
+// P² algorithm of Jain and Chlamtac (1985), which tracks five markers whose
+// heights are adjusted by piecewise-parabolic interpolation. Estimates cannot
+// be merged; keep one estimator per stream. P2Quantile is not safe for
+// concurrent use.
+type P2Quantile struct {
+	p     float64
+	count int
+	q     [5]float64 // marker heights
+	pos   [5]float64 // actual marker positions
+	want  [5]float64 // desired marker positions
+	step  [5]float64 // desired position increments
+}
+
+// NewP2Quantile returns an estimator for the p-quantile. It returns an error
+// unless p lies in (0, 1).
+func NewP2Quantile(p float64) (*P2Quantile, error) {
+	if !(p > 0 && p < 1) {
+		return nil, fmt.Errorf("stats: P² quantile level %v outside (0, 1)", p)
+	}
+	return &P2Quantile{
+		p:    p,
+		pos:  [5]float64{0, 1, 2, 3, 4},
+		want: [5]float64{0, 2 * p, 4 * p, 2 + 2*p, 4},
+		step: [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}, nil
+}
+
+// N returns the number of observations.
+func (e *P2Quantile) N() int {
+	return e.count
+}
+
+func (e *P2Quantile) Add(x float64) {
+	if e.count < 5 {
+		e.q[e.count] = x
+		e.count++
+		if e.count == 5 {
+			slices.Sort(e.q[:])
+		}
+		return
+	}
+	e.count++
+
+	var k int
+	switch {
+	case x < e.q[0]:
+		e.q[0] = x
+		k = 0
+	case x >= e.q[4]:
+		e.q[4] = x
+		k = 3
+	default:
+		for k = 0; x >= e.q[k+1]; k++ {
+		}
+	}
+	for i := k + 1; i < 5; i++ {
+		e.pos[i]++
+	}
+	for i := range e.want {
+		e.want[i] += e.step[i]
+	}
+
+	for i := 1; i <= 3; i++ {
+		d := e.want[i] - e.pos[i]
+		if (d >= 1 && e.pos[i+1]-e.pos[i] > 1) || (d <= -1 && e.pos[i-1]-e.pos[i] < -1) {
+			s := math.Copysign(1, d)
+			q := e.parabolic(i, s)
+			if !(e.q[i-1] < q && q < e.q[i+1]) {
+				j := i + int(s)
+				q = e.q[i] + s*(e.q[j]-e.q[i])/(e.pos[j]-e.pos[i])
+			}
+			e.q[i] = q
+			e.pos[i] += s
+		}
+	}
+}
+
+// parabolic returns the P² prediction for marker i moved by s = ±1.
+func (e *P2Quantile) parabolic(i int, s float64) float64 {
+	q, n := &e.q, &e.pos
+	return q[i] + s/(n[i+1]-n[i-1])*
+		((n[i]-n[i-1]+s)*(q[i+1]-q[i])/(n[i+1]-n[i])+
+			(n[i+1]-n[i]-s)*(q[i]-q[i-1])/(n[i]-n[i-1]))
+}
+
+// Value returns the current estimate: the exact quantile while fewer than
+// five observations have been seen, or NaN before the first.
+func (e *P2Quantile) Value() float64 {
+	if e.count < 5 {
+		return Quantile(e.q[:e.count], e.p)
+	}
+	return e.q[2]
+}
@@ -0,0 +1,44 @@
+package stats
+
+import (
+	"math"
+	"slices"
+)
+
+// Quantile returns the p-quantile of xs by linear interpolation between order
+// statistics (Hyndman and Fan's type 7, the default of R and NumPy). xs is not
+// modified. It returns NaN if xs is empty or p is outside [0, 1].
+func Quantile(xs []float64, p float64) float64 {
+	return Quantiles(xs, p)[0]
+}
+
+// Quantiles returns the quantiles of xs at each of ps, sorting a copy of xs
+// only once.
+func Quantiles(xs []float64, ps ...float64) []float64 {
+	sorted := slices.Clone(xs)
+	slices.Sort(sorted)
+	out := make([]float64, len(ps))
+	for i, p := range ps {
+		out[i] = QuantileSorted(sorted, p)
+	}
+	return out
+}
+
+// QuantileSorted is Quantile for an already sorted slice.
+func QuantileSorted(sorted []float64, p float64) float64 {
+	n := len(sorted)
+	if n == 0 || !(p >= 0 && p <= 1) {
+		return math.NaN()
+	}
+	h := float64(n-1) * p
+	lo := int(h)
+	if lo >= n-1 {
+		return sorted[n-1]
+	}
+	return sorted[lo] + (h-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
+
+// Median returns Quantile(xs, 0.5).
+func Median(xs []float64) float64 {
+	return Quantile(xs, 0.5)
+}
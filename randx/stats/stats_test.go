@@ -0,0 +1,194 @@
+package stats_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/miguelm-revel/revelTools/randx"
+	"github.com/miguelm-revel/revelTools/randx/stats"
+	"github.com/miguelm-revel/revelTools/syncx"
+)
+
+func near(got, want, tol float64) bool {
+	return math.Abs(got-want) <= tol*math.Max(1, math.Abs(want))
+}
+
+// twoPass computes the summary statistics directly from the slice.
+func twoPass(xs []float64) (mean, variance, skew, kurt float64) {
+	n := float64(len(xs))
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= n
+	var m2, m3, m4 float64
+	for _, x := range xs {
+		d := x - mean
+		m2 += d * d
+		m3 += d * d * d
+		m4 += d * d * d * d
+	}
+	m2, m3, m4 = m2/n, m3/n, m4/n
+	return mean, m2 * n / (n - 1), m3 / math.Pow(m2, 1.5), m4/(m2*m2) - 3
+}
+
+func TestSummary_MatchesTwoPass(t *testing.T) {
+	xs := randx.Sample(randx.GammaDist{Shape: 2, Scale: 3}, 10000)
+	for i := range xs {
+		xs[i] += 1e6 // a large offset exposes naive sum-of-squares updates
+	}
+	var s stats.Summary
+	for _, x := range xs {
+		s.Add(x)
+	}
+	mean, variance, skew, kurt := twoPass(xs)
+	if s.N() != int64(len(xs)) || !near(s.Mean(), mean, 1e-12) || !near(s.Variance(), variance, 1e-9) ||
+		!near(s.Skewness(), skew, 1e-6) || !near(s.ExKurtosis(), kurt, 1e-6) {
+		t.Errorf("summary (%v, %v, %v, %v), want (%v, %v, %v, %v)",
+			s.Mean(), s.Variance(), s.Skewness(), s.ExKurtosis(), mean, variance, skew, kurt)
+	}
+	lo, hi := xs[0], xs[0]
+	for _, x := range xs {
+		lo, hi = math.Min(lo, x), math.Max(hi, x)
+	}
+	if s.Min() != lo || s.Max() != hi {
+		t.Errorf("extrema (%v, %v), want (%v, %v)", s.Min(), s.Max(), lo, hi)
+	}
+}
+
+func TestSummary_MergeEqualsSequential(t *testing.T) {
+	xs := randx.Sample(randx.LogNormalDist{Mu: 0, Sigma: 1}, 3001)
+	var all stats.Summary
+	for _, x := range xs {
+		all.Add(x)
+	}
+	// Uneven parts, including an empty one, exercise every merge branch.
+	var merged stats.Summary
+	for _, part := range [][]float64{xs[:0], xs[:7], xs[7:1500], xs[1500:]} {
+		var s stats.Summary
+		for _, x := range part {
+			s.Add(x)
+		}
+		merged.Merge(s)
+	}
+	checks := []struct {
+		name      string
+		got, want float64
+	}{
+		{"mean", merged.Mean(), all.Mean()},
+		{"variance", merged.Variance(), all.Variance()},
+		{"skewness", merged.Skewness(), all.Skewness()},
+		{"kurtosis", merged.ExKurtosis(), all.ExKurtosis()},
+		{"min", merged.Min(), all.Min()},
+		{"max", merged.Max(), all.Max()},
+	}
+	for _, c := range checks {
+		if !near(c.got, c.want, 1e-10) {
+			t.Errorf("%s: merged %v, sequential %v", c.name, c.got, c.want)
+		}
+	}
+	if merged.N() != all.N() {
+		t.Errorf("N: merged %d, sequential %d", merged.N(), all.N())
+	}
+}
+
+func TestSummary_Empty(t *testing.T) {
+	var s stats.Summary
+	for name, v := range map[string]float64{
+		"mean": s.Mean(), "variance": s.Variance(), "min": s.Min(), "max": s.Max(), "skewness": s.Skewness(),
+	} {
+		if !math.IsNaN(v) {
+			t.Errorf("%s of empty summary = %v, want NaN", name, v)
+		}
+	}
+	s.Add(4)
+	if s.Mean() != 4 || !math.IsNaN(s.Variance()) {
+		t.Errorf("single observation: mean %v, variance %v", s.Mean(), s.Variance())
+	}
+}
+
+func TestGoSummary_WorkerPool(t *testing.T) {
+	const jobs, perJob = 16, 1000
+	var shared stats.GoSummary
+	pool := syncx.NewWorkerPool(4, jobs)
+	for j := range jobs {
+		pool.Submit(func() error {
+			r := randx.NewRand(uint64(j))
+			var local stats.Summary
+			for range perJob {
+				local.Add(randx.ExpDist{Lambda: 2}.RandWith(r))
+			}
+			shared.Merge(local)
+			shared.Add(0.5)
+			return nil
+		})
+	}
+	pool.Close()
+	if errs := pool.Wait(); len(errs) != 0 {
+		t.Fatal(errs)
+	}
+	if n := shared.Snapshot().N(); n != jobs*(perJob+1) {
+		t.Fatalf("N = %d, want %d", n, jobs*(perJob+1))
+	}
+	if mean := shared.Snapshot().Mean(); !near(mean, 0.5, 0.02) {
+		t.Errorf("mean = %v, want ≈ 0.5", mean)
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	xs := []float64{7, 1, 3, 5, 9}
+	cases := []struct{ p, want float64 }{
+		{0, 1}, {0.25, 3}, {0.5, 5}, {0.6, 5.8}, {1, 9},
+	}
+	for _, c := range cases {
+		if got := stats.Quantile(xs, c.p); !near(got, c.want, 1e-15) {
+			t.Errorf("Quantile(%v) = %v, want %v", c.p, got, c.want)
+		}
+	}
+	if xs[0] != 7 {
+		t.Errorf("Quantile modified its input: %v", xs)
+	}
+	if got := stats.Median([]float64{4, 1, 3, 2}); got != 2.5 {
+		t.Errorf("Median = %v, want 2.5", got)
+	}
+	for _, got := range []float64{stats.Quantile(nil, 0.5), stats.Quantile(xs, -0.1), stats.Quantile(xs, math.NaN())} {
+		if !math.IsNaN(got) {
+			t.Errorf("invalid quantile = %v, want NaN", got)
+		}
+	}
+}
+
+func TestP2Quantile(t *testing.T) {
+	d := randx.NormalDist{Mu: 10, Sigma: 2}
+	r := randx.NewRand(5)
+	for _, p := range []float64{0.05, 0.5, 0.9, 0.99} {
+		e, err := stats.NewP2Quantile(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for range 100000 {
+			e.Add(d.RandWith(r))
+		}
+		if want := d.Quantile(p); math.Abs(e.Value()-want) > 0.05 {
+			t.Errorf("P²(%v) = %v, want ≈ %v", p, e.Value(), want)
+		}
+	}
+
+	for _, p := range []float64{0, 1, -0.5, math.NaN()} {
+		if _, err := stats.NewP2Quantile(p); err == nil {
+			t.Errorf("NewP2Quantile(%v) succeeded", p)
+		}
+	}
+	e, err := stats.NewP2Quantile(0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !math.IsNaN(e.Value()) {
+		t.Errorf("empty estimator = %v, want NaN", e.Value())
+	}
+	for _, x := range []float64{3, 1, 2} {
+		e.Add(x)
+	}
+	if e.Value() != 2 || e.N() != 3 {
+		t.Errorf("small-sample estimate = %v after %d, want exact median 2", e.Value(), e.N())
+	}
+}
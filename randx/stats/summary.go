@@ -0,0 +1,156 @@
+// Package stats provides descriptive statistics over samples: a mergeable
+// streaming Summary, exact quantiles over slices and the P² streaming
+// quantile estimator, none of which need to retain the stream.
+package stats
+
+import (
+	"math"
+	"sync"
+)
+
+// Summary accumulates the count, mean, central moments up to the fourth and
+// the extrema of a stream in O(1) memory, using Welford's update and Pébay's
+// pairwise formulas for the higher moments. The zero value is an empty
+// summary. Summary is not safe for concurrent use; give each goroutine its
+// own and Merge them, or use GoSummary.
+type Summary struct {
+	n                int64
+	mean, m2, m3, m4 float64
+	min, max         float64
+}
+
+// Add folds x into the summary.
+func (s *Summary) Add(x float64) {
+	n1 := float64(s.n)
+	s.n++
+	n := float64(s.n)
+	delta := x - s.mean
+	dn := delta / n
+	dn2 := dn * dn
+	term := delta * dn * n1
+	s.mean += dn
+	s.m4 += term*dn2*(n*n-3*n+3) + 6*dn2*s.m2 - 4*dn*s.m3
+	s.m3 += term*dn*(n-2) - 3*dn*s.m2
+	s.m2 += term
+	if s.n == 1 || x < s.min {
+		s.min = x
+	}
+	if s.n == 1 || x > s.max {
+		s.max = x
+	}
+}
+
+// Merge folds the observations summarized by o into s, as if each had been
+// passed to Add.
+func (s *Summary) Merge(o Summary) {
+	if o.n == 0 {
+		return
+	}
+	if s.n == 0 {
+		*s = o
+		return
+	}
+	na, nb := float64(s.n), float64(o.n)
+	n := na + nb
+	d := o.mean - s.mean
+	d2 := d * d
+	m2 := s.m2 + o.m2 + d2*na*nb/n
+	m3 := s.m3 + o.m3 + d*d2*na*nb*(na-nb)/(n*n) + 3*d*(na*o.m2-nb*s.m2)/n
+	m4 := s.m4 + o.m4 + d2*d2*na*nb*(na*na-na*nb+nb*nb)/(n*n*n) +
+		6*d2*(na*na*o.m2+nb*nb*s.m2)/(n*n) + 4*d*(na*o.m3-nb*s.m3)/n
+	s.mean += d * nb / n
+	s.m2, s.m3, s.m4 = m2, m3, m4
+	s.n += o.n
+	s.min = math.Min(s.min, o.min)
+	s.max = math.Max(s.max, o.max)
+}
+
+// N returns the number of observations.
+func (s Summary) N() int64 {
+	return s.n
+}
+
+// Mean returns the sample mean, or NaN if the summary is empty.
+func (s Summary) Mean() float64 {
+	if s.n == 0 {
+		return math.NaN()
+	}
+	return s.mean
+}
+
+// Variance returns the unbiased sample variance, or NaN with fewer than two
+// observations.
+func (s Summary) Variance() float64 {
+	if s.n < 2 {
+		return math.NaN()
+	}
+	return s.m2 / float64(s.n-1)
+}
+
+func (s Summary) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// Min returns the smallest observation, or NaN if the summary is empty.
+func (s Summary) Min() float64 {
+	if s.n == 0 {
+		return math.NaN()
+	}
+	return s.min
+}
+
+// Max returns the largest observation, or NaN if the summary is empty.
+func (s Summary) Max() float64 {
+	if s.n == 0 {
+		return math.NaN()
+	}
+	return s.max
+}
+
+// Skewness returns the moment coefficient of skewness g₁ = m₃ / m₂^(3/2),
+// where mₖ are the biased sample central moments.
+func (s Summary) Skewness() float64 {
+	if s.n < 2 || s.m2 == 0 {
+		return math.NaN()
+	}
+	n := float64(s.n)
+	return math.Sqrt(n) * s.m3 / math.Pow(s.m2, 1.5)
+}
+
+// ExKurtosis returns the excess kurtosis g₂ = m₄ / m₂² - 3.
+func (s Summary) ExKurtosis() float64 {
+	if s.n < 2 || s.m2 == 0 {
+		return math.NaN()
+	}
+	n := float64(s.n)
+	return n*s.m4/(s.m2*s.m2) - 3
+}
+
+// GoSummary is a Summary guarded by a mutex so that concurrent producers can
+// share it. The zero value is an empty summary.
+type GoSummary struct {
+	mu sync.Mutex
+	s  Summary
+}
+
+func (g *GoSummary) Add(x float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.s.Add(x)
+}
+
+// Merge folds a summary built elsewhere into g. Batching observations in a
+// local Summary and merging it once is much cheaper than calling Add under
+// contention.
+func (g *GoSummary) Merge(o Summary) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.s.Merge(o)
+}
+
+// Snapshot returns a copy of the current summary.
+func (g *GoSummary) Snapshot() Summary {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.s
+}
@@ -0,0 +1,43 @@
+package stattest
+
+import (
+	"errors"
+	"math"
+
+	"github.com/miguelm-revel/revelTools/randx"
+)
+
+// BinomTest runs the exact binomial test of k successes in n trials against
+// success probability p. The statistic is the observed proportion k/n. The
+// two-sided p-value sums the probabilities of every outcome no more likely
+// than k. DF is NaN.
+func BinomTest(k, n int, p float64, alt Alternative) (Result, error) {
+	if n < 1 || k < 0 || k > n {
+		return Result{}, errors.New("stattest: need 0 <= k <= n and n >= 1")
+	}
+	if !(p >= 0 && p <= 1) {
+		return Result{}, errors.New("stattest: p must be in [0, 1]")
+	}
+	d := randx.BinomDist{N: n, P: p}
+	var pv float64
+	switch alt {
+	case Less:
+		pv = d.CDF(float64(k))
+	case Greater:
+		pv = d.Survival(float64(k - 1))
+	default:
+		// The relative slack absorbs rounding in PMF values that are equal in
+		// exact arithmetic.
+		limit := d.PDF(float64(k)) * (1 + 1e-7)
+		for i := 0; i <= n; i++ {
+			if q := d.PDF(float64(i)); q <= limit {
+				pv += q
+			}
+		}
+	}
+	return Result{
+		Statistic: float64(k) / float64(n),
+		PValue:    math.Min(1, pv),
+		DF:        math.NaN(),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package stattest
+
+import (
+	"errors"
+	"math"
+
+	"github.com/miguelm-revel/revelTools/randx"
+)
+
+// ChiSquareGOF runs Pearson's goodness-of-fit test of the observed counts
+// against expected, which may be counts or unnormalized probabilities and is
+// rescaled to the observed total. ddof is the number of parameters estimated
+// from the data; the test has len(observed) - 1 - ddof degrees of freedom.
+func ChiSquareGOF(observed, expected []float64, ddof int) (Result, error) {
+	k := len(observed)
+	if k != len(expected) {
+		return Result{}, errors.New("stattest: observed and expected differ in length")
+	}
+	df := k - 1 - ddof
+	if df < 1 {
+		return Result{}, errors.New("stattest: chi-square test has no degrees of freedom")
+	}
+	var nObs, nExp float64
+	for i := range observed {
+		if observed[i] < 0 || !(expected[i] > 0) || math.IsInf(expected[i], 0) {
+			return Result{}, errors.New("stattest: counts must be non-negative and expected counts positive")
+		}
+		nObs += observed[i]
+		nExp += expected[i]
+	}
+	scale := nObs / nExp
+	stat := 0.0
+	for i, o := range observed {
+		e := expected[i] * scale
+		stat += (o - e) * (o - e) / e
+	}
+	return chiSquareResult(stat, df), nil
+}
+
+// ChiSquareIndependence runs Pearson's test of independence between the rows
+// and columns of a contingency table, with (rows-1)(cols-1) degrees of
+// freedom.
+func ChiSquareIndependence(table [][]float64) (Result, error) {
+	r := len(table)
+	if r < 2 || len(table[0]) < 2 {
+		return Result{}, errors.New("stattest: contingency table must be at least 2x2")
+	}
+	c := len(table[0])
+	rows := make([]float64, r)
+	cols := make([]float64, c)
+	total := 0.0
+	for i, row := range table {
+		if len(row) != c {
+			return Result{}, errors.New("stattest: contingency table rows differ in length")
+		}
+		for j, v := range row {
+			if v < 0 || math.IsNaN(v) || math.IsInf(v, 0) {
+				return Result{}, errors.New("stattest: counts must be finite and non-negative")
+			}
+			rows[i] += v
+			cols[j] += v
+			total += v
+		}
+	}
+	stat := 0.0
+	for i, row := range table {
+		for j, o := range row {
+			e := rows[i] * cols[j] / total
+			if e == 0 {
+				return Result{}, errors.New("stattest: contingency table has an empty row or column")
+			}
+			stat += (o - e) * (o - e) / e
+		}
+	}
+	return chiSquareResult(stat, (r-1)*(c-1)), nil
+}
+
+func chiSquareResult(stat float64, df int) Result {
+	return Result{
+		Statistic: stat,
+		PValue:    randx.Chi2Dist{K: float64(df)}.Survival(stat),
+		DF:        float64(df),
+	}
+}
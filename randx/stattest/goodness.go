@@ -0,0 +1,127 @@
+package stattest
+
+import (
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/miguelm-revel/revelTools/randx"
+)
+
+// sortedSample returns a sorted copy of xs, or an error if xs is empty or
+// holds NaN.
+func sortedSample(xs []float64) ([]float64, error) {
+	if len(xs) == 0 {
+		return nil, errors.New("stattest: empty sample")
+	}
+	s := slices.Clone(xs)
+	for _, x := range s {
+		if math.IsNaN(x) {
+			return nil, errors.New("stattest: sample contains NaN")
+		}
+	}
+	slices.Sort(s)
+	return s, nil
+}
+
+// KolmogorovSmirnov tests xs against the fully specified distribution d. The
+// statistic is sup |F_n(x) - F(x)|, and the p-value uses the asymptotic
+// Kolmogorov distribution with Stephens' small-sample correction. DF is NaN.
+func KolmogorovSmirnov(xs []float64, d randx.Dist) (Result, error) {
+	s, err := sortedSample(xs)
+	if err != nil {
+		return Result{}, err
+	}
+	n := float64(len(s))
+	stat := 0.0
+	for i, x := range s {
+		f := d.CDF(x)
+		stat = math.Max(stat, math.Max(float64(i+1)/n-f, f-float64(i)/n))
+	}
+	sn := math.Sqrt(n)
+	return Result{
+		Statistic: stat,
+		PValue:    kolmogorovSurvival((sn + 0.12 + 0.11/sn) * stat),
+		DF:        math.NaN(),
+	}, nil
+}
+
+// kolmogorovSurvival returns P(K > x) for the Kolmogorov distribution,
+// 2 Σ (-1)^(k-1) exp(-2k²x²).
+func kolmogorovSurvival(x float64) float64 {
+	if x < 0.2 {
+		return 1
+	}
+	sum := 0.0
+	for k := 1; k <= 100; k++ {
+		term := math.Exp(-2 * float64(k*k) * x * x)
+		if k%2 == 0 {
+			term = -term
+		}
+		sum += term
+		if term == 0 || math.Abs(term) < 1e-16*sum {
+			break
+		}
+	}
+	return math.Max(0, math.Min(1, 2*sum))
+}
+
+// AndersonDarling tests xs against the fully specified distribution d. The
+// statistic is computed from log-CDF and log-survival values so that points
+// deep in the tails do not overflow it, and the p-value uses Marsaglia and
+// Marsaglia's (2004) approximation with its finite-n correction. DF is NaN.
+func AndersonDarling(xs []float64, d randx.Dist) (Result, error) {
+	s, err := sortedSample(xs)
+	if err != nil {
+		return Result{}, err
+	}
+	n := len(s)
+	sum := 0.0
+	for i, x := range s {
+		sum += float64(2*i+1) * (randx.LogCDF(d, x) + randx.LogSurvival(d, s[n-1-i]))
+	}
+	stat := -float64(n) - sum/float64(n)
+	return Result{
+		Statistic: stat,
+		PValue:    1 - adCDF(n, stat),
+		DF:        math.NaN(),
+	}, nil
+}
+
+// adCDF returns P(A² <= z) for a sample of size n.
+func adCDF(n int, z float64) float64 {
+	if z <= 0 {
+		return 0
+	}
+	if math.IsInf(z, 1) {
+		return 1
+	}
+	x := adInf(z)
+	return math.Max(0, math.Min(1, x+adErrFix(n, x)))
+}
+
+// adInf is the limiting distribution of A² as n → ∞.
+func adInf(z float64) float64 {
+	if z < 2 {
+		return math.Exp(-1.2337141/z) / math.Sqrt(z) *
+			(2.00012 + (0.247105-(0.0649821-(0.0347962-(0.011672-0.00168691*z)*z)*z)*z)*z)
+	}
+	return math.Exp(-math.Exp(1.0776 - (2.30695-(0.43424-(0.082433-(0.008056-0.0003146*z)*z)*z)*z)*z))
+}
+
+// adErrFix corrects adInf at probability x for a finite sample of size n.
+func adErrFix(n int, x float64) float64 {
+	fn := float64(n)
+	if x > 0.8 {
+		return (-130.2137 + (745.2337-(1705.091-(1950.646-(1116.360-255.7844*x)*x)*x)*x)*x) / fn
+	}
+	c := 0.01265 + 0.1757/fn
+	if x < c {
+		t := x / c
+		t = math.Sqrt(t) * (1 - t) * (49*t - 102)
+		return t * (0.0037/(fn*fn) + 0.00078/fn + 0.00006) / fn
+	}
+	t := (x - c) / (0.8 - c)
+	t = -0.00022633 + (6.54034-(14.6538-(14.458-(8.259-1.91864*t)*t)*t)*t)*t
+	return t * (0.04213 + 0.01365/fn) / fn
+}
@@ -0,0 +1,93 @@
+package stattest
+
+import (
+	"errors"
+	"math"
+
+	"github.com/miguelm-revel/revelTools/randx"
+)
+
+var stdNormal = randx.NormalDist{Mu: 0, Sigma: 1}
+
+// ZTest tests whether the mean of xs equals mu0 when the population standard
+// deviation sigma is known. DF is NaN.
+func ZTest(xs []float64, mu0, sigma float64, alt Alternative) (Result, error) {
+	if len(xs) == 0 {
+		return Result{}, errors.New("stattest: empty sample")
+	}
+	if !(sigma > 0) {
+		return Result{}, errors.New("stattest: sigma must be positive")
+	}
+	mean, _ := meanVar(xs)
+	z := (mean - mu0) / (sigma / math.Sqrt(float64(len(xs))))
+	return Result{Statistic: z, PValue: tailP(stdNormal, z, alt), DF: math.NaN()}, nil
+}
+
+// ZTest2 tests whether the means of xs and ys are equal when their
+// population standard deviations are known. DF is NaN.
+func ZTest2(xs, ys []float64, sigmaX, sigmaY float64, alt Alternative) (Result, error) {
+	if len(xs) == 0 || len(ys) == 0 {
+		return Result{}, errors.New("stattest: empty sample")
+	}
+	if !(sigmaX > 0) || !(sigmaY > 0) {
+		return Result{}, errors.New("stattest: sigma must be positive")
+	}
+	mx, _ := meanVar(xs)
+	my, _ := meanVar(ys)
+	se := math.Sqrt(sigmaX*sigmaX/float64(len(xs)) + sigmaY*sigmaY/float64(len(ys)))
+	z := (mx - my) / se
+	return Result{Statistic: z, PValue: tailP(stdNormal, z, alt), DF: math.NaN()}, nil
+}
+
+// TTest runs Student's one-sample t-test of whether the mean of xs equals
+// mu0, with n-1 degrees of freedom.
+func TTest(xs []float64, mu0 float64, alt Alternative) (Result, error) {
+	if len(xs) < 2 {
+		return Result{}, errors.New("stattest: t-test needs at least two observations")
+	}
+	mean, variance := meanVar(xs)
+	if variance == 0 {
+		return Result{}, errors.New("stattest: sample has zero variance")
+	}
+	n := float64(len(xs))
+	return tResult((mean-mu0)/math.Sqrt(variance/n), n-1, alt), nil
+}
+
+// TTest2 runs Student's two-sample t-test with pooled variance, which
+// assumes xs and ys share a common variance. It has n_x + n_y - 2 degrees of
+// freedom.
+func TTest2(xs, ys []float64, alt Alternative) (Result, error) {
+	if len(xs) < 2 || len(ys) < 2 {
+		return Result{}, errors.New("stattest: t-test needs at least two observations per sample")
+	}
+	mx, vx := meanVar(xs)
+	my, vy := meanVar(ys)
+	nx, ny := float64(len(xs)), float64(len(ys))
+	df := nx + ny - 2
+	pooled := ((nx-1)*vx + (ny-1)*vy) / df
+	if pooled == 0 {
+		return Result{}, errors.New("stattest: samples have zero variance")
+	}
+	return tResult((mx-my)/math.Sqrt(pooled*(1/nx+1/ny)), df, alt), nil
+}
+
+// WelchTTest runs Welch's two-sample t-test, which does not assume equal
+// variances. DF is the Welch–Satterthwaite approximation and is generally
+// fractional.
+func WelchTTest(xs, ys []float64, alt Alternative) (Result, error) {
+	if len(xs) < 2 || len(ys) < 2 {
+		return Result{}, errors.New("stattest: t-test needs at least two observations per sample")
+	}
+	mx, vx := meanVar(xs)
+	my, vy := meanVar(ys)
+	ax, ay := vx/float64(len(xs)), vy/float64(len(ys))
+	if ax+ay == 0 {
+		return Result{}, errors.New("stattest: samples have zero variance")
+	}
+	df := (ax + ay) * (ax + ay) / (ax*ax/float64(len(xs)-1) + ay*ay/float64(len(ys)-1))
+	return tResult((mx-my)/math.Sqrt(ax+ay), df, alt), nil
+}
+
+func tResult(t, df float64, alt Alternative) Result {
+	return Result{Statistic: t, PValue: tailP(randx.StudentTDist{Nu: df}, t, alt), DF: df}
+}
@@ -0,0 +1,57 @@
+// Package stattest implements classical hypothesis tests on top of the randx
+// distributions, which supply the null distributions of the statistics.
+package stattest
+
+import (
+	"math"
+
+	"github.com/miguelm-revel/revelTools/randx"
+)
+
+// Result is the outcome of a test. DF holds the degrees of freedom of the
+// null distribution, or NaN for tests whose null distribution has none.
+type Result struct {
+	Statistic float64
+	PValue    float64
+	DF        float64
+}
+
+// Alternative selects the alternative hypothesis of a test with a
+// directional statistic.
+type Alternative int
+
+const (
+	// TwoSided rejects for extreme statistics in either direction.
+	TwoSided Alternative = iota
+	// Less rejects for small statistics.
+	Less
+	// Greater rejects for large statistics.
+	Greater
+)
+
+// tailP converts a statistic with a symmetric continuous null distribution d
+// into a p-value under alt.
+func tailP(d randx.Dist, stat float64, alt Alternative) float64 {
+	switch alt {
+	case Less:
+		return d.CDF(stat)
+	case Greater:
+		return randx.Survival(d, stat)
+	default:
+		return math.Min(1, 2*randx.Survival(d, math.Abs(stat)))
+	}
+}
+
+// meanVar returns the mean and unbiased variance of xs.
+func meanVar(xs []float64) (mean, variance float64) {
+	for _, x := range xs {
+		mean += x
+	}
+	n := float64(len(xs))
+	mean /= n
+	for _, x := range xs {
+		d := x - mean
+		variance += d * d
+	}
+	return mean, variance / (n - 1)
+}
@@ -0,0 +1,191 @@
+package stattest
+
+import (
+	"math"
+	"testing"
+
+	"github.com/miguelm-revel/revelTools/randx"
+)
+
+func near(got, want, tol float64) bool {
+	return math.Abs(got-want) <= tol
+}
+
+func TestStattest_ReferenceValues(t *testing.T) {
+	// Reference p-values use closed forms: the chi-square survival function
+	// for 4 and 1 degrees of freedom, the Student-t CDF for 2 and erfc.
+	gof, err := ChiSquareGOF([]float64{22, 18, 25, 15, 20}, []float64{1, 1, 1, 1, 1}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ind, err := ChiSquareIndependence([][]float64{{20, 15}, {30, 35}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tt, err := TTest([]float64{5.1, 4.9, 5.6}, 5, TwoSided)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zs := []float64{1.2, 0.8, 1.9, 1.4}
+	z2, err := ZTest(zs, 1, 0.5, TwoSided)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zg, err := ZTest(zs, 1, 0.5, Greater)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name        string
+		got         Result
+		stat, p, df float64
+	}{
+		{"chi-square gof", gof, 2.9, 0.5746972058298043, 4},
+		{"chi-square independence", ind, 1.098901098901099, 0.2945073936801102, 1},
+		{"t-test", tt, 0.9607689228305244, 0.4380485130509829, 2},
+		{"z-test two-sided", z2, 1.3, 0.19360096917122074, math.NaN()},
+		{"z-test greater", zg, 1.3, 0.09680048458561037, math.NaN()},
+	}
+	for _, c := range cases {
+		if !near(c.got.Statistic, c.stat, 1e-12) || !near(c.got.PValue, c.p, 1e-9) {
+			t.Errorf("%s: got %+v, want statistic %v, p %v", c.name, c.got, c.stat, c.p)
+		}
+		if c.got.DF != c.df && !(math.IsNaN(c.df) && math.IsNaN(c.got.DF)) {
+			t.Errorf("%s: DF = %v, want %v", c.name, c.got.DF, c.df)
+		}
+	}
+}
+
+func TestStattest_BinomTestExact(t *testing.T) {
+	cases := []struct {
+		k, n int
+		p    float64
+		alt  Alternative
+		want float64
+	}{
+		{3, 10, 0.5, TwoSided, 352.0 / 1024},
+		{3, 10, 0.5, Less, 176.0 / 1024},
+		{8, 10, 0.5, Greater, 56.0 / 1024},
+		{0, 10, 0.5, Greater, 1},
+		{5, 10, 0.5, TwoSided, 1},
+		// Under n = 4 and p = 0.3 every outcome but X = 1 (0.4116) is at most as
+		// likely as X = 2 (0.2646).
+		{2, 4, 0.3, TwoSided, 1 - 0.4116},
+	}
+	for _, c := range cases {
+		got, err := BinomTest(c.k, c.n, c.p, c.alt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !near(got.PValue, c.want, 1e-12) {
+			t.Errorf("BinomTest(%d, %d, %v, %v) p = %v, want %v", c.k, c.n, c.p, c.alt, got.PValue, c.want)
+		}
+	}
+}
+
+func TestStattest_TwoSampleT(t *testing.T) {
+	xs := []float64{19.8, 20.4, 19.6, 17.8, 18.5, 18.9, 18.3, 18.9, 19.5, 22.0}
+	ys := []float64{28.2, 26.6, 20.1, 23.3, 25.2, 22.1, 17.7, 27.6, 20.6, 13.7, 23.2, 17.5, 20.6, 18.0, 23.9, 21.6, 24.3, 20.4, 23.9, 13.3}
+	pooled, err := TTest2(xs, ys, TwoSided)
+	if err != nil {
+		t.Fatal(err)
+	}
+	welch, err := WelchTTest(xs, ys, TwoSided)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !near(pooled.Statistic, -1.6544465858663975, 1e-12) || pooled.DF != 28 {
+		t.Errorf("pooled = %+v", pooled)
+	}
+	if !near(welch.Statistic, -2.2255120399698485, 1e-12) || !near(welch.DF, 24.524634944257343, 1e-9) {
+		t.Errorf("Welch = %+v", welch)
+	}
+	// Unequal variances shrink the pooled statistic relative to Welch's.
+	if pooled.PValue <= welch.PValue {
+		t.Errorf("pooled p %v should exceed Welch p %v", pooled.PValue, welch.PValue)
+	}
+	// With equal sample variances the two tests agree on the statistic.
+	a := []float64{1, 2, 3, 4}
+	b := []float64{3, 4, 5, 6}
+	p1, _ := TTest2(a, b, Less)
+	p2, _ := WelchTTest(a, b, Less)
+	if !near(p1.Statistic, p2.Statistic, 1e-12) || !near(p2.DF, 6, 1e-12) {
+		t.Errorf("pooled %+v, Welch %+v", p1, p2)
+	}
+}
+
+func TestStattest_DistributionFree(t *testing.T) {
+	// Asymptotic 5% critical values.
+	if p := kolmogorovSurvival(1.3580986393225507); !near(p, 0.05, 1e-9) {
+		t.Errorf("Kolmogorov survival at 1.358 = %v, want 0.05", p)
+	}
+	if p := 1 - adInf(2.492); !near(p, 0.05, 5e-4) {
+		t.Errorf("Anderson–Darling limit at 2.492 = %v, want 0.05", p)
+	}
+
+	ks, err := KolmogorovSmirnov([]float64{0.1, 0.4, 0.7}, randx.UniformDist{Min: 0, Max: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !near(ks.Statistic, 0.3, 1e-12) {
+		t.Errorf("KS statistic = %v, want 0.3", ks.Statistic)
+	}
+
+	r := randx.NewRand(17)
+	null := randx.NormalDist{Mu: 0, Sigma: 1}
+	draw := func(d randx.Dist) []float64 {
+		xs := make([]float64, 400)
+		for i := range xs {
+			xs[i] = d.RandWith(r)
+		}
+		return xs
+	}
+	tests := map[string]func([]float64, randx.Dist) (Result, error){
+		"KS": KolmogorovSmirnov,
+		"AD": AndersonDarling,
+	}
+	for name, test := range tests {
+		// Under the null the p-values are roughly uniform, so a fixed seed
+		// should not reject at 0.1% across ten samples.
+		for range 10 {
+			res, err := test(draw(null), null)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.PValue < 0.001 {
+				t.Errorf("%s rejected the null: %+v", name, res)
+			}
+		}
+		res, err := test(draw(randx.NormalDist{Mu: 0.4, Sigma: 1}), null)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.PValue > 1e-4 {
+			t.Errorf("%s failed to reject a shifted sample: %+v", name, res)
+		}
+	}
+}
+
+func TestStattest_RejectsInvalidInput(t *testing.T) {
+	errs := map[string]error{}
+	_, errs["gof length"] = ChiSquareGOF([]float64{1, 2}, []float64{1}, 0)
+	_, errs["gof df"] = ChiSquareGOF([]float64{1, 2}, []float64{1, 1}, 1)
+	_, errs["gof zero expected"] = ChiSquareGOF([]float64{1, 2, 3}, []float64{1, 0, 1}, 0)
+	_, errs["independence shape"] = ChiSquareIndependence([][]float64{{1, 2}})
+	_, errs["independence ragged"] = ChiSquareIndependence([][]float64{{1, 2}, {3}})
+	_, errs["independence empty column"] = ChiSquareIndependence([][]float64{{1, 0}, {3, 0}})
+	_, errs["ks empty"] = KolmogorovSmirnov(nil, randx.NormalDist{Sigma: 1})
+	_, errs["ad NaN"] = AndersonDarling([]float64{math.NaN()}, randx.NormalDist{Sigma: 1})
+	_, errs["z sigma"] = ZTest([]float64{1}, 0, 0, TwoSided)
+	_, errs["t short"] = TTest([]float64{1}, 0, TwoSided)
+	_, errs["t constant"] = TTest([]float64{1, 1}, 0, TwoSided)
+	_, errs["welch short"] = WelchTTest([]float64{1, 2}, []float64{1}, TwoSided)
+	_, errs["binom k"] = BinomTest(11, 10, 0.5, TwoSided)
+	_, errs["binom p"] = BinomTest(1, 10, 1.5, TwoSided)
+	for name, err := range errs {
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,397 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// TruncatedDist is D conditioned on Lo < X <= Hi. The interval is open on the
+// left so that truncating a discrete distribution at integer bounds behaves
+// like CDF differences: NewTruncated(d, 2, 5) keeps the values 3, 4 and 5.
+// Either bound may be infinite.
+type TruncatedDist struct {
+	D      Dist
+	Lo, Hi float64
+}
+
+// NewTruncated validates and returns TruncatedDist{D: d, Lo: lo, Hi: hi}.
+func NewTruncated(d Dist, lo, hi float64) (TruncatedDist, error) {
+	t := TruncatedDist{D: d, Lo: lo, Hi: hi}
+	return t, t.Validate()
+}
+
+// Validate also rejects an interval to which D assigns no probability.
+func (t TruncatedDist) Validate() error {
+	_, err := t.interval()
+	return err
+}
+
+// minQuantileSurvival is the smallest survival value invSurvival maps
+// through Quantile(1 - s); rounding 1 - s costs at most 1e-10 of s there.
+const minQuantileSurvival = 1e-6
+
+// truncInterval locates (Lo, Hi] within D. When Lo lies above D's median the
+// interval is measured from the right with survival values, so that 1 - CDF
+// does not round away an upper tail: base is then Survival(Lo) rather than
+// CDF(Lo), and mass is Survival(Lo) - Survival(Hi).
+type truncInterval struct {
+	upper      bool
+	base, mass float64
+}
+
+// interval returns where (Lo, Hi] sits in D and the probability D assigns to it.
+func (t TruncatedDist) interval() (truncInterval, error) {
+	if err := validateInner("TruncatedDist", "D", t.D); err != nil {
+		return truncInterval{}, err
+	}
+	if !(t.Lo < t.Hi) {
+		return truncInterval{}, paramError("TruncatedDist", "Hi", t.Hi, "> Lo")
+	}
+	var iv truncInterval
+	if sLo := Survival(t.D, t.Lo); sLo < 0.5 {
+		iv = truncInterval{upper: true, base: sLo, mass: sLo - Survival(t.D, t.Hi)}
+	} else {
+		iv.base = t.D.CDF(t.Lo)
+		iv.mass = t.D.CDF(t.Hi) - iv.base
+	}
+	if !(iv.mass > 0) {
+		return truncInterval{}, paramError("TruncatedDist", "Hi", t.Hi, "an interval with positive probability")
+	}
+	return iv, nil
+}
+
+func (t TruncatedDist) Rand() float64 {
+	return t.RandWith(nil)
+}
+
+// RandWith samples by inversion, mapping a uniform into (CDF(lo), CDF(hi)]
+// through D's quantile function, or into (Survival(hi), Survival(lo)] through
+// the inverse survival function for an upper tail. When D does not implement
+// Quantiler it draws from D until a sample lands in the interval, which is
+// efficient only while the interval holds a fair share of the mass.
+func (t TruncatedDist) RandWith(r *rand.Rand) float64 {
+	iv, err := t.interval()
+	if err != nil {
+		return math.NaN()
+	}
+	return t.draw(r, iv)
+}
+
+// FillWith computes the probability of the interval once for the whole batch.
+func (t TruncatedDist) FillWith(r *rand.Rand, dst []float64) {
+	iv, err := t.interval()
+	if err != nil {
+		fillNaN(dst)
+		return
+	}
+	for i := range dst {
+		dst[i] = t.draw(r, iv)
+	}
+}
+
+func (t TruncatedDist) draw(r *rand.Rand, iv truncInterval) float64 {
+	var x float64
+	if iv.upper {
+		x = t.invSurvival(iv.base - iv.mass + (1-uniform(r))*iv.mass)
+	} else {
+		q, ok := t.D.(Quantiler)
+		if !ok {
+			for {
+				if x := t.D.RandWith(r); x > t.Lo && x <= t.Hi {
+					return x
+				}
+			}
+		}
+		x = q.Quantile(iv.base + (1-uniform(r))*iv.mass)
+	}
+	// Rounding in the quantile can step just outside the bounds.
+	return math.Min(math.Max(x, math.Nextafter(t.Lo, math.Inf(1))), t.Hi)
+}
+
+// invSurvival returns the smallest x in [Lo, Hi] with Survival(x) <= s. It
+// is only used for upper tails, where Lo is finite. D's Quantile at 1 - s
+// answers directly while s is large enough for 1 - s to keep its precision;
+// smaller survival values, and any D without Quantile, fall back to bisection
+// on Survival.
+func (t TruncatedDist) invSurvival(s float64) float64 {
+	if q, ok := t.D.(Quantiler); ok && s >= minQuantileSurvival {
+		return q.Quantile(1 - s)
+	}
+	lo, hi := t.Lo, t.Hi
+	if math.IsInf(hi, 1) {
+		step := math.Max(1, math.Abs(lo))
+		for hi = lo + step; Survival(t.D, hi) > s && hi < math.MaxFloat64/4; hi = lo + step {
+			step *= 2
+		}
+	}
+	for range 2000 {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			break
+		}
+		if Survival(t.D, mid) <= s {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi
+}
+
+func (t TruncatedDist) PDF(x float64) float64 {
+	iv, err := t.interval()
+	if err != nil {
+		return math.NaN()
+	}
+	if x <= t.Lo || x > t.Hi {
+		return 0
+	}
+	return t.D.PDF(x) / iv.mass
+}
+
+func (t TruncatedDist) CDF(x float64) float64 {
+	iv, err := t.interval()
+	if err != nil {
+		return math.NaN()
+	}
+	if x <= t.Lo {
+		return 0
+	}
+	if x >= t.Hi {
+		return 1
+	}
+	if iv.upper {
+		return math.Min(1, math.Max(0, (iv.base-Survival(t.D, x))/iv.mass))
+	}
+	return math.Min(1, math.Max(0, (t.D.CDF(x)-iv.base)/iv.mass))
+}
+
+func (t TruncatedDist) Quantile(p float64) float64 {
+	iv, err := t.interval()
+	if err != nil || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	var x float64
+	switch {
+	case !iv.upper:
+		x = quantileOf(t.D, iv.base+p*iv.mass)
+	case p == 1:
+		x = t.Hi
+	default:
+		x = t.invSurvival(iv.base - p*iv.mass)
+	}
+	return math.Min(math.Max(x, t.Lo), t.Hi)
+}
+
+// AffineDist is the distribution of Loc + Scale·X for X drawn from D. Scale
+// must be positive. PDF divides D's density by Scale, so it is a density only
+// for continuous D; see DiscretizedDist for the reverse direction.
+type AffineDist struct {
+	D          Dist
+	Loc, Scale float64
+}
+
+// NewAffine validates and returns AffineDist{D: d, Loc: loc, Scale: scale}.
+func NewAffine(d Dist, loc, scale float64) (AffineDist, error) {
+	a := AffineDist{D: d, Loc: loc, Scale: scale}
+	return a, a.Validate()
+}
+
+// NewShifted validates and returns the distribution of X + by.
+func NewShifted(d Dist, by float64) (AffineDist, error) {
+	return NewAffine(d, by, 1)
+}
+
+// NewScaled validates and returns the distribution of X·by, with by > 0.
+func NewScaled(d Dist, by float64) (AffineDist, error) {
+	return NewAffine(d, 0, by)
+}
+
+func (a AffineDist) Validate() error {
+	if !finite(a.Loc) {
+		return paramError("AffineDist", "Loc", a.Loc, "finite")
+	}
+	if !positive(a.Scale) {
+		return paramError("AffineDist", "Scale", a.Scale, "> 0")
+	}
+	return validateInner("AffineDist", "D", a.D)
+}
+
+func (a AffineDist) Rand() float64 {
+	return a.RandWith(nil)
+}
+
+func (a AffineDist) RandWith(r *rand.Rand) float64 {
+	if a.Validate() != nil {
+		return math.NaN()
+	}
+	return a.Loc + a.Scale*a.D.RandWith(r)
+}
+
+func (a AffineDist) PDF(x float64) float64 {
+	if a.Validate() != nil {
+		return math.NaN()
+	}
+	return a.D.PDF((x-a.Loc)/a.Scale) / a.Scale
+}
+
+func (a AffineDist) CDF(x float64) float64 {
+	if a.Validate() != nil {
+		return math.NaN()
+	}
+	return a.D.CDF((x - a.Loc) / a.Scale)
+}
+
+func (a AffineDist) Quantile(p float64) float64 {
+	if a.Validate() != nil {
+		return math.NaN()
+	}
+	return a.Loc + a.Scale*quantileOf(a.D, p)
+}
+
+// CensoredDist is D with samples clamped into [Lo, Hi]: the mass of D below
+// Lo sits at Lo and the mass above Hi sits at Hi. PDF returns D's density
+// strictly inside the interval and those point masses at the bounds, which is
+// the likelihood contribution of a censored observation.
+type CensoredDist struct {
+	D      Dist
+	Lo, Hi float64
+}
+
+// NewCensored validates and returns d clamped to [lo, hi]. Either bound may
+// be infinite.
+func NewCensored(d Dist, lo, hi float64) (CensoredDist, error) {
+	c := CensoredDist{D: d, Lo: lo, Hi: hi}
+	return c, c.Validate()
+}
+
+func (c CensoredDist) Validate() error {
+	if !(c.Lo <= c.Hi) {
+		return paramError("CensoredDist", "Hi", c.Hi, ">= Lo")
+	}
+	return validateInner("CensoredDist", "D", c.D)
+}
+
+func (c CensoredDist) Rand() float64 {
+	return c.RandWith(nil)
+}
+
+func (c CensoredDist) RandWith(r *rand.Rand) float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Min(math.Max(c.D.RandWith(r), c.Lo), c.Hi)
+}
+
+func (c CensoredDist) PDF(x float64) float64 {
+	switch {
+	case c.Validate() != nil:
+		return math.NaN()
+	case x < c.Lo || x > c.Hi:
+		return 0
+	case c.Lo == c.Hi:
+		// The whole mass sits at the single point Lo = Hi.
+		return 1
+	case x == c.Lo:
+		return c.D.CDF(c.Lo)
+	case x == c.Hi:
+		return Survival(c.D, math.Nextafter(c.Hi, math.Inf(-1)))
+	}
+	return c.D.PDF(x)
+}
+
+func (c CensoredDist) CDF(x float64) float64 {
+	switch {
+	case c.Validate() != nil:
+		return math.NaN()
+	case x < c.Lo:
+		return 0
+	case x >= c.Hi:
+		return 1
+	}
+	return c.D.CDF(x)
+}
+
+func (c CensoredDist) Quantile(p float64) float64 {
+	if c.Validate() != nil {
+		return math.NaN()
+	}
+	x := quantileOf(c.D, p)
+	if math.IsNaN(x) {
+		return x
+	}
+	return math.Min(math.Max(x, c.Lo), c.Hi)
+}
+
+// DiscretizedDist is the distribution of ⌊X⌋ for X drawn from D, so a
+// continuous D becomes an integer-valued one with P(k) = F(k+1) - F(k).
+// Combine it with AffineDist to discretize on another grid.
+type DiscretizedDist struct {
+	D Dist
+}
+
+// NewDiscretized validates and returns the distribution of ⌊X⌋ for X drawn
+// from d.
+func NewDiscretized(d Dist) (DiscretizedDist, error) {
+	dd := DiscretizedDist{D: d}
+	return dd, dd.Validate()
+}
+
+func (d DiscretizedDist) Validate() error {
+	return validateInner("DiscretizedDist", "D", d.D)
+}
+
+func (d DiscretizedDist) Rand() float64 {
+	return d.RandWith(nil)
+}
+
+func (d DiscretizedDist) RandWith(r *rand.Rand) float64 {
+	if d.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Floor(d.D.RandWith(r))
+}
+
+// PDF returns P(⌊X⌋ = x) for integer x, taking the difference of survival
+// values in the upper half so that tail probabilities keep their precision.
+func (d DiscretizedDist) PDF(x float64) float64 {
+	if d.Validate() != nil {
+		return math.NaN()
+	}
+	if x != math.Floor(x) {
+		return 0
+	}
+	lo := d.D.CDF(x)
+	if lo > 0.5 {
+		return math.Max(0, Survival(d.D, x)-Survival(d.D, x+1))
+	}
+	return math.Max(0, d.D.CDF(x+1)-lo)
+}
+
+func (d DiscretizedDist) CDF(x float64) float64 {
+	if d.Validate() != nil {
+		return math.NaN()
+	}
+	return d.D.CDF(math.Floor(x) + 1)
+}
+
+// Quantile returns the smallest integer k with F(k+1) >= p, which is
+// ⌈Q(p)⌉ - 1 for the quantile function Q of a continuous D.
+func (d DiscretizedDist) Quantile(p float64) float64 {
+	if d.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Ceil(quantileOf(d.D, p)) - 1
+}
+
+// validateInner validates the distribution a combinator wraps, if it can,
+// reporting a nil one as the field of dist.
+func validateInner(dist, field string, d Dist) error {
+	if d == nil {
+		return paramError(dist, field, math.NaN(), "non-nil")
+	}
+	if v, ok := d.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
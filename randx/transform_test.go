@@ -0,0 +1,400 @@
+package randx
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+var (
+	_ Quantiler = MixtureDist{}
+	_ Quantiler = TruncatedDist{}
+	_ Quantiler = AffineDist{}
+	_ Quantiler = CensoredDist{}
+	_ Quantiler = DiscretizedDist{}
+)
+
+// cdfOnly hides every method of a Dist but the four in the interface, to
+// exercise the numeric fallbacks.
+type cdfOnly struct{ Dist }
+
+func TestMixture_Bimodal(t *testing.T) {
+	a, b := NormalDist{Mu: -2, Sigma: 0.5}, NormalDist{Mu: 3, Sigma: 1}
+	m, err := NewMixture([]Dist{a, b}, []float64{1, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := m.Weights; w[0] != 0.25 || w[1] != 0.75 {
+		t.Errorf("weights = %v", w)
+	}
+	for _, x := range []float64{-2, 0, 3} {
+		want := 0.25*a.PDF(x) + 0.75*b.PDF(x)
+		if got := m.PDF(x); math.Abs(got-want) > 1e-15 {
+			t.Errorf("PDF(%v) = %v, want %v", x, got, want)
+		}
+	}
+	if area := simpson(m.PDF, -10, 12, 4000); math.Abs(area-1) > 1e-9 {
+		t.Errorf("PDF integrates to %v", area)
+	}
+	if got, want := m.Mean(), 0.25*-2+0.75*3; math.Abs(got-want) > 1e-12 {
+		t.Errorf("Mean = %v, want %v", got, want)
+	}
+	// Var = Σ w(σ² + μ²) - μ² = 0.25·4.25 + 0.75·10 - 1.75².
+	if got, want := m.Variance(), 0.25*4.25+0.75*10-1.75*1.75; math.Abs(got-want) > 1e-12 {
+		t.Errorf("Variance = %v, want %v", got, want)
+	}
+	for _, p := range []float64{0.1, 0.25, 0.5, 0.9} {
+		if got := m.CDF(m.Quantile(p)); math.Abs(got-p) > 1e-12 {
+			t.Errorf("CDF(Quantile(%v)) = %v", p, got)
+		}
+	}
+	if stat := ksStatistic(Sample(m, 20000), m.CDF); stat > ksCritical(20000) {
+		t.Errorf("KS statistic %v", stat)
+	}
+	if _, err := NewMixture([]Dist{a}, []float64{1, 2}); err == nil {
+		t.Error("expected an error for mismatched lengths")
+	}
+	if _, err := NewMixture([]Dist{a}, []float64{0}); err == nil {
+		t.Error("expected an error for zero weights")
+	}
+	if _, err := NewMixture([]Dist{a, nil}, []float64{1, 1}); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("nil component: error = %v", err)
+	}
+	var pe *ParamError
+	if _, err := NewMixture([]Dist{a, ExpDist{Lambda: -1}}, []float64{1, 1}); !errors.As(err, &pe) || pe.Dist != "ExpDist" {
+		t.Errorf("invalid component: error = %v", err)
+	}
+	if x := (MixtureDist{}).PDF(0); !math.IsNaN(x) {
+		t.Errorf("zero MixtureDist PDF = %v, want NaN", x)
+	}
+}
+
+func TestTruncated_PositiveNormal(t *testing.T) {
+	n := NormalDist{Mu: 1, Sigma: 2}
+	for name, d := range map[string]Dist{"quantile": n, "rejection": cdfOnly{n}} {
+		tr, err := NewTruncated(d, 0, math.Inf(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		mass := 1 - n.CDF(0)
+		if got, want := tr.PDF(1), n.PDF(1)/mass; math.Abs(got-want) > 1e-15 {
+			t.Errorf("%s: PDF(1) = %v, want %v", name, got, want)
+		}
+		if tr.PDF(-0.5) != 0 || tr.CDF(0) != 0 {
+			t.Errorf("%s: mass below the bound", name)
+		}
+		xs := make([]float64, 20000)
+		FillWith(tr, NewRand(8), xs)
+		for _, x := range xs {
+			if x <= 0 {
+				t.Fatalf("%s: sample %v outside (0, ∞)", name, x)
+			}
+		}
+		if stat := ksStatistic(xs, tr.CDF); stat > ksCritical(len(xs)) {
+			t.Errorf("%s: KS statistic %v", name, stat)
+		}
+		if got := tr.CDF(tr.Quantile(0.3)); math.Abs(got-0.3) > 1e-9 {
+			t.Errorf("%s: CDF(Quantile(0.3)) = %v", name, got)
+		}
+	}
+
+	// Discrete truncation keeps lo < k <= hi.
+	p := PoissonDist{Lambda: 4}
+	tr, err := NewTruncated(p, 2, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mass := p.PDF(3) + p.PDF(4) + p.PDF(5)
+	if got := tr.PDF(3); math.Abs(got-p.PDF(3)/mass) > 1e-15 || tr.PDF(2) != 0 {
+		t.Errorf("PDF(3) = %v, PDF(2) = %v", got, tr.PDF(2))
+	}
+	for range 1000 {
+		if k := tr.Rand(); k < 3 || k > 5 {
+			t.Fatalf("sample %v outside {3, 4, 5}", k)
+		}
+	}
+
+	if _, err := NewTruncated(n, 2, 1); err == nil {
+		t.Error("expected an error for lo >= hi")
+	}
+	if _, err := NewTruncated(ExpDist{Lambda: 1}, -2, -1); err == nil {
+		t.Error("expected an error for an empty interval")
+	}
+	var pe *ParamError
+	if _, err := NewTruncated(ExpDist{Lambda: -1}, 0, 1); !errors.As(err, &pe) || pe.Dist != "ExpDist" {
+		t.Errorf("invalid inner distribution: error = %v", err)
+	}
+	if x := (TruncatedDist{}).CDF(0); !math.IsNaN(x) {
+		t.Errorf("zero TruncatedDist CDF = %v, want NaN", x)
+	}
+}
+
+func TestTruncated_InfiniteDiscreteBound(t *testing.T) {
+	for name, d := range map[string]Dist{
+		"poisson":  PoissonDist{Lambda: 3},
+		"binomial": BinomDist{N: 10, P: 0.4},
+	} {
+		tr, err := NewTruncated(d, 2, math.Inf(1))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		mass := 1 - d.CDF(2)
+		if got, want := tr.PDF(3), d.PDF(3)/mass; math.Abs(got-want) > 1e-15 {
+			t.Errorf("%s: PDF(3) = %v, want %v", name, got, want)
+		}
+		if got := tr.CDF(1e20); got != 1 {
+			t.Errorf("%s: CDF(1e20) = %v, want 1", name, got)
+		}
+		for range 1000 {
+			if k := tr.Rand(); k < 3 {
+				t.Fatalf("%s: sample %v below the bound", name, k)
+			}
+		}
+	}
+}
+
+func TestAffine(t *testing.T) {
+	std := NormalDist{Mu: 0, Sigma: 1}
+	a := AffineDist{D: std, Loc: 5, Scale: 3}
+	want := NormalDist{Mu: 5, Sigma: 3}
+	for _, x := range []float64{-1, 5, 9.5} {
+		if math.Abs(a.PDF(x)-want.PDF(x)) > 1e-15 || math.Abs(a.CDF(x)-want.CDF(x)) > 1e-15 {
+			t.Errorf("at %v: PDF %v CDF %v, want %v %v", x, a.PDF(x), a.CDF(x), want.PDF(x), want.CDF(x))
+		}
+	}
+	if got := a.Quantile(0.975); math.Abs(got-want.Quantile(0.975)) > 1e-12 {
+		t.Errorf("Quantile(0.975) = %v", got)
+	}
+	shifted, err := NewShifted(ExpDist{Lambda: 1}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := shifted.CDF(2); got != 0 {
+		t.Errorf("shifted CDF at the new origin = %v", got)
+	}
+	scaled, err := NewScaled(ExpDist{Lambda: 1}, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := scaled.CDF(4); math.Abs(got-(1-math.Exp(-1))) > 1e-15 {
+		t.Errorf("scaled CDF = %v", got)
+	}
+	if got := (AffineDist{D: cdfOnly{std}, Loc: 1, Scale: 2}).Quantile(0.8); math.Abs(got-(1+2*std.Quantile(0.8))) > 1e-9 {
+		t.Errorf("numeric quantile = %v", got)
+	}
+	zero, err := NewScaled(std, 0)
+	if !errors.Is(err, ErrInvalidParameter) || !math.IsNaN(zero.Rand()) {
+		t.Errorf("non-positive scale: error = %v, sample %v", err, zero.Rand())
+	}
+	if _, err := NewShifted(std, math.Inf(1)); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("infinite shift: error = %v", err)
+	}
+}
+
+func TestCensored(t *testing.T) {
+	n := NormalDist{Mu: 0, Sigma: 1}
+	c, err := NewCensored(n, -1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := c.PDF(-1); got != n.CDF(-1) {
+		t.Errorf("mass at Lo = %v, want %v", got, n.CDF(-1))
+	}
+	if got, want := c.PDF(2), 1-n.CDF(2); math.Abs(got-want) > 1e-15 {
+		t.Errorf("mass at Hi = %v, want %v", got, want)
+	}
+	if c.PDF(0.5) != n.PDF(0.5) || c.PDF(3) != 0 {
+		t.Error("density inside or outside the interval is wrong")
+	}
+	if c.CDF(-1.5) != 0 || c.CDF(2) != 1 || c.CDF(0) != 0.5 {
+		t.Error("CDF is wrong")
+	}
+	atLo, atHi := 0, 0
+	const draws = 100000
+	for range draws {
+		switch x := c.Rand(); {
+		case x == -1:
+			atLo++
+		case x == 2:
+			atHi++
+		case x < -1 || x > 2:
+			t.Fatalf("sample %v outside [-1, 2]", x)
+		}
+	}
+	if got := float64(atLo) / draws; math.Abs(got-n.CDF(-1)) > 0.005 {
+		t.Errorf("fraction at Lo = %v, want ≈ %v", got, n.CDF(-1))
+	}
+	if got := float64(atHi) / draws; math.Abs(got-(1-n.CDF(2))) > 0.003 {
+		t.Errorf("fraction at Hi = %v, want ≈ %v", got, 1-n.CDF(2))
+	}
+	if c.Quantile(0.01) != -1 || c.Quantile(0.999) != 2 {
+		t.Error("quantiles are not clamped")
+	}
+	if _, err := NewCensored(n, 2, 1); !errors.Is(err, ErrInvalidParameter) {
+		t.Errorf("lo > hi: error = %v", err)
+	}
+	// Censoring to a single point leaves a unit mass there.
+	point, err := NewCensored(n, 0.5, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := point.PDF(0.5); got != 1 {
+		t.Errorf("Lo = Hi: mass at the point = %v, want 1", got)
+	}
+	if x := point.Rand(); x != 0.5 {
+		t.Errorf("Lo = Hi: sample %v, want 0.5", x)
+	}
+}
+
+func TestDiscretized_ExpIsGeometric(t *testing.T) {
+	// ⌊X⌋ for X ~ Exp(λ) is geometric with p = 1 - e^-λ.
+	e := ExpDist{Lambda: 0.7}
+	d, err := NewDiscretized(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := GeometricDist{P: 1 - math.Exp(-0.7)}
+	for k := 0.0; k < 60; k++ {
+		if got, want := d.PDF(k), g.PDF(k); math.Abs(got-want) > 1e-13*math.Max(want, 1e-3) {
+			t.Errorf("PDF(%v) = %v, want %v", k, got, want)
+		}
+		if got, want := d.CDF(k+0.5), g.CDF(k); math.Abs(got-want) > 1e-15 {
+			t.Errorf("CDF(%v) = %v, want %v", k+0.5, got, want)
+		}
+	}
+	for _, p := range []float64{0.1, 0.5, 0.99} {
+		if got, want := d.Quantile(p), g.Quantile(p); got != want {
+			t.Errorf("Quantile(%v) = %v, want %v", p, got, want)
+		}
+	}
+	if d.PDF(1.5) != 0 {
+		t.Error("PDF at a non-integer should be 0")
+	}
+	xs := make([]float64, 200000)
+	FillWith(d, NewRand(4), xs)
+	if stat, crit := chiSquareFit(g, xs); stat > crit {
+		t.Errorf("chi-square %v exceeds %v", stat, crit)
+	}
+}
+
+func TestTruncated_UpperTail(t *testing.T) {
+	n := NormalDist{Mu: 0, Sigma: 1}
+	tr, err := NewTruncated(n, 8, math.Inf(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	xs := make([]float64, 10000)
+	FillWith(tr, NewRand(12), xs)
+	distinct := map[float64]bool{}
+	var sum float64
+	for _, x := range xs {
+		if x <= 8 {
+			t.Fatalf("sample %v outside (8, ∞)", x)
+		}
+		distinct[x] = true
+		sum += x
+	}
+	if len(distinct) < 9990 {
+		t.Errorf("only %d distinct values in %d draws", len(distinct), len(xs))
+	}
+	// E[X | X > a] = φ(a) / Q(a) for the standard normal.
+	if got, want := sum/float64(len(xs)), n.PDF(8)/n.Survival(8); math.Abs(got-want) > 0.01 {
+		t.Errorf("sample mean %v, want %v", got, want)
+	}
+	if stat := ksStatistic(xs, tr.CDF); stat > ksCritical(len(xs)) {
+		t.Errorf("KS statistic %v", stat)
+	}
+	if got, want := tr.PDF(8.1), n.PDF(8.1)/n.Survival(8); math.Abs(got/want-1) > 1e-12 {
+		t.Errorf("PDF(8.1) = %v, want %v", got, want)
+	}
+	for _, p := range []float64{0.1, 0.5, 0.99} {
+		if got := tr.CDF(tr.Quantile(p)); math.Abs(got-p) > 1e-9 {
+			t.Errorf("CDF(Quantile(%v)) = %v", p, got)
+		}
+	}
+
+	far, err := NewTruncated(n, 9, math.Inf(1))
+	if err != nil {
+		t.Fatalf("(9, ∞): %v", err)
+	}
+	if x := far.Rand(); !(x > 9) {
+		t.Errorf("sample %v outside (9, ∞)", x)
+	}
+	band, err := NewTruncated(n, 9, 10)
+	if err != nil {
+		t.Fatalf("(9, 10]: %v", err)
+	}
+	if got := band.Quantile(1); got != 10 {
+		t.Errorf("Quantile(1) = %v, want 10", got)
+	}
+	if got := band.CDF(9.5); !(got > 0.99 && got < 1) {
+		t.Errorf("CDF(9.5) = %v", got)
+	}
+}
+
+// countingExp counts the Quantile and Survival evaluations made on it.
+type countingExp struct {
+	ExpDist
+	quantiles, survivals *int
+}
+
+func (c countingExp) Quantile(p float64) float64 {
+	*c.quantiles++
+	return c.ExpDist.Quantile(p)
+}
+
+func (c countingExp) Survival(x float64) float64 {
+	*c.survivals++
+	return c.ExpDist.Survival(x)
+}
+
+func TestTruncated_UpperTailUsesQuantile(t *testing.T) {
+	var quantiles, survivals int
+	d := countingExp{ExpDist{Lambda: 1}, &quantiles, &survivals}
+	tr := TruncatedDist{D: d, Lo: 3, Hi: math.Inf(1)}
+	xs := make([]float64, 1000)
+	tr.FillWith(NewRand(4), xs)
+	if quantiles != len(xs) || survivals > 2 {
+		t.Errorf("%d draws made %d Quantile and %d Survival calls", len(xs), quantiles, survivals)
+	}
+	// The exponential is memoryless: X | X > 3 is 3 + Exp(1).
+	sum := 0.0
+	for _, x := range xs {
+		if x <= 3 {
+			t.Fatalf("sample %v outside (3, ∞)", x)
+		}
+		sum += x
+	}
+	if mean := sum / float64(len(xs)); math.Abs(mean-4) > 0.15 {
+		t.Errorf("sample mean %v, want ≈ 4", mean)
+	}
+}
+
+func TestCombinators_NilInner(t *testing.T) {
+	for name, d := range map[string]interface {
+		Dist
+		Quantiler
+		Validator
+	}{
+		"AffineDist":      AffineDist{Scale: 1},
+		"CensoredDist":    CensoredDist{Lo: 0, Hi: 1},
+		"DiscretizedDist": DiscretizedDist{},
+	} {
+		var pe *ParamError
+		if err := d.Validate(); !errors.As(err, &pe) || pe.Dist != name || pe.Field != "D" {
+			t.Errorf("%s: Validate() = %v", name, err)
+		}
+		if x := d.Rand(); !math.IsNaN(x) {
+			t.Errorf("%s: Rand() = %v, want NaN", name, x)
+		}
+		if x := d.PDF(0.5); !math.IsNaN(x) {
+			t.Errorf("%s: PDF = %v, want NaN", name, x)
+		}
+		if x := d.CDF(0.5); !math.IsNaN(x) {
+			t.Errorf("%s: CDF = %v, want NaN", name, x)
+		}
+		if x := d.Quantile(0.5); !math.IsNaN(x) {
+			t.Errorf("%s: Quantile = %v, want NaN", name, x)
+		}
+	}
+}
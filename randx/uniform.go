@@ -0,0 +1,164 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+type UniformDist struct {
+	Min, Max float64
+}
+
+// NewUniform validates and returns UniformDist{Min: min, Max: max}.
+func NewUniform(min, max float64) (UniformDist, error) {
+	d := UniformDist{Min: min, Max: max}
+	return d, d.Validate()
+}
+
+func (u UniformDist) Validate() error {
+	if !finite(u.Min) {
+		return paramError("UniformDist", "Min", u.Min, "finite")
+	}
+	if !finite(u.Max) || u.Max <= u.Min {
+		return paramError("UniformDist", "Max", u.Max, "finite and > Min")
+	}
+	return nil
+}
+
+func (u UniformDist) Rand() float64 {
+	return u.RandWith(nil)
+}
+
+func (u UniformDist) RandWith(r *rand.Rand) float64 {
+	if u.Validate() != nil {
+		return math.NaN()
+	}
+	return u.Min + (u.Max-u.Min)*uniform(r)
+}
+
+func (u UniformDist) Sample(n int) []float64 {
+	dst := make([]float64, n)
+	u.Fill(dst)
+	return dst
+}
+
+func (u UniformDist) Fill(dst []float64) {
+	u.FillWith(nil, dst)
+}
+
+// FillWith validates the bounds once for the whole batch.
+func (u UniformDist) FillWith(r *rand.Rand, dst []float64) {
+	if u.Validate() != nil {
+		fillNaN(dst)
+		return
+	}
+	width := u.Max - u.Min
+	for i := range dst {
+		dst[i] = u.Min + width*uniform(r)
+	}
+}
+
+func (u UniformDist) PDF(x float64) float64 {
+	if u.Validate() != nil || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < u.Min || x > u.Max {
+		return 0
+	}
+	return 1 / (u.Max - u.Min)
+}
+
+func (u UniformDist) CDF(x float64) float64 {
+	if u.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= u.Min {
+		return 0
+	}
+	if x >= u.Max {
+		return 1
+	}
+	return (x - u.Min) / (u.Max - u.Min)
+}
+
+func (u UniformDist) Quantile(p float64) float64 {
+	if u.Validate() != nil || math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return u.Min + p*(u.Max-u.Min)
+}
+
+func (u UniformDist) Mean() float64 {
+	if u.Validate() != nil {
+		return math.NaN()
+	}
+	return 0.5 * (u.Min + u.Max)
+}
+
+func (u UniformDist) Variance() float64 {
+	if u.Validate() != nil {
+		return math.NaN()
+	}
+	w := u.Max - u.Min
+	return w * w / 12
+}
+
+func (u UniformDist) StdDev() float64 {
+	return math.Sqrt(u.Variance())
+}
+
+func (u UniformDist) Skewness() float64 {
+	if u.Validate() != nil {
+		return math.NaN()
+	}
+	return 0
+}
+
+func (u UniformDist) ExKurtosis() float64 {
+	if u.Validate() != nil {
+		return math.NaN()
+	}
+	return -6.0 / 5.0
+}
+
+// Mode returns the midpoint of the interval; every point of [Min, Max] is a
+// mode of the uniform distribution.
+func (u UniformDist) Mode() float64 {
+	return u.Mean()
+}
+
+func (u UniformDist) Median() float64 {
+	return u.Mean()
+}
+
+func (u UniformDist) Entropy() float64 {
+	if u.Validate() != nil {
+		return math.NaN()
+	}
+	return math.Log(u.Max - u.Min)
+}
+
+func (u UniformDist) LogPDF(x float64) float64 {
+	return math.Log(u.PDF(x))
+}
+
+func (u UniformDist) LogCDF(x float64) float64 {
+	return math.Log(u.CDF(x))
+}
+
+func (u UniformDist) Survival(x float64) float64 {
+	if u.Validate() != nil {
+		return math.NaN()
+	}
+	if x <= u.Min {
+		return 1
+	}
+	if x >= u.Max {
+		return 0
+	}
+	return (u.Max - x) / (u.Max - u.Min)
+}
+
+func (u UniformDist) LogSurvival(x float64) float64 {
+	return math.Log(u.Survival(x))
+}
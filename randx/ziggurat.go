@@ -0,0 +1,124 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+/* -----------------------------
+   Ziggurat (Marsaglia & Tsang, 2000) para normal y exponencial estándar.
+   Las tablas se construyen en init; el índice de capa y el valor se toman
+   de mitades distintas del mismo uint64 para que no estén correlacionados.
+------------------------------*/
+
+const (
+	zigNormR = 3.442619855899
+	zigNormV = 9.91256303526217e-3
+	zigExpR  = 7.697117470131487
+	zigExpV  = 3.949659822581572e-3
+)
+
+var (
+	zigKn        [128]uint32
+	zigWn, zigFn [128]float64
+	zigKe        [256]uint32
+	zigWe, zigFe [256]float64
+)
+
+func init() {
+	const m1 = 1 << 31
+	dn := zigNormR
+	tn := dn
+	q := zigNormV / math.Exp(-0.5*dn*dn)
+	zigKn[0] = uint32(dn / q * m1)
+	zigKn[1] = 0
+	zigWn[0] = q / m1
+	zigWn[127] = dn / m1
+	zigFn[0] = 1
+	zigFn[127] = math.Exp(-0.5 * dn * dn)
+	for i := 126; i >= 1; i-- {
+		dn = math.Sqrt(-2 * math.Log(zigNormV/dn+math.Exp(-0.5*dn*dn)))
+		zigKn[i+1] = uint32(dn / tn * m1)
+		tn = dn
+		zigFn[i] = math.Exp(-0.5 * dn * dn)
+		zigWn[i] = dn / m1
+	}
+
+	const m2 = 1 << 32
+	de := zigExpR
+	te := de
+	q = zigExpV / math.Exp(-de)
+	zigKe[0] = uint32(de / q * m2)
+	zigKe[1] = 0
+	zigWe[0] = q / m2
+	zigWe[255] = de / m2
+	zigFe[0] = 1
+	zigFe[255] = math.Exp(-de)
+	for i := 254; i >= 1; i-- {
+		de = -math.Log(zigExpV/de + math.Exp(-de))
+		zigKe[i+1] = uint32(de / te * m2)
+		te = de
+		zigFe[i] = math.Exp(-de)
+		zigWe[i] = de / m2
+	}
+}
+
+// stdNormal devuelve una variable N(0, 1).
+func stdNormal(r *rand.Rand) float64 {
+	for {
+		u := uint64With(r)
+		// La capa combina dos grupos de bits por XOR, así que no cambia al
+		// complementar u, mientras que j pasa a -j-1: la fuente antitética de
+		// MonteCarlo obtiene entonces -x.
+		i := (u ^ u>>8) & 0x7f
+		j := int32(u >> 32)
+		x := float64(j) * zigWn[i]
+
+		abs := uint32(j)
+		if j < 0 {
+			abs = uint32(-j)
+		}
+		if abs < zigKn[i] {
+			return x
+		}
+
+		if i == 0 {
+			// Cola más allá de R (Marsaglia, 1964).
+			for {
+				x = -math.Log(uniformPos(r)) / zigNormR
+				y := -math.Log(uniformPos(r))
+				if y+y >= x*x {
+					break
+				}
+			}
+			if j > 0 {
+				return zigNormR + x
+			}
+			return -zigNormR - x
+		}
+
+		if zigFn[i]+uniform(r)*(zigFn[i-1]-zigFn[i]) < math.Exp(-0.5*x*x) {
+			return x
+		}
+	}
+}
+
+// stdExp devuelve una variable Exp(1).
+func stdExp(r *rand.Rand) float64 {
+	for {
+		u := uint64With(r)
+		i := u & 0xff
+		j := uint32(u >> 32)
+		x := float64(j) * zigWe[i]
+		if j < zigKe[i] {
+			return x
+		}
+		if i == 0 {
+			// La cola exponencial es otra exponencial desplazada en R.
+			return zigExpR - math.Log(uniformPos(r))
+		}
+		if zigFe[i]+uniform(r)*(zigFe[i-1]-zigFe[i]) < math.Exp(-x) {
+			return x
+		}
+	}
+}
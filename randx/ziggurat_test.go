@@ -0,0 +1,123 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// ksStatistic returns the Kolmogorov–Smirnov distance between xs and cdf.
+func ksStatistic(xs []float64, cdf func(float64) float64) float64 {
+	xs = slices.Clone(xs)
+	slices.Sort(xs)
+	n := float64(len(xs))
+	d := 0.0
+	for i, x := range xs {
+		f := cdf(x)
+		d = max(d, f-float64(i)/n, float64(i+1)/n-f)
+	}
+	return d
+}
+
+// ksCritical is the asymptotic KS critical value at significance 1e-6.
+func ksCritical(n int) float64 {
+	return math.Sqrt(-0.5*math.Log(0.5e-6)) / math.Sqrt(float64(n))
+}
+
+func boxMuller(r *rand.Rand) float64 {
+	rad := math.Sqrt(-2.0 * math.Log(uniformPos(r)))
+	return rad * math.Cos(2.0*math.Pi*uniform(r))
+}
+
+func expInversion(r *rand.Rand) float64 {
+	return -math.Log(uniformPos(r))
+}
+
+func TestZiggurat_MatchesReferenceDistributions(t *testing.T) {
+	const n = 500000
+	cases := []struct {
+		name   string
+		sample func(*rand.Rand) float64
+		cdf    func(float64) float64
+		mean   float64
+		vari   float64
+	}{
+		{"normal", stdNormal, NormalDist{Mu: 0, Sigma: 1}.CDF, 0, 1},
+		{"exp", stdExp, ExpDist{Lambda: 1}.CDF, 1, 1},
+	}
+	for _, c := range cases {
+		r := NewRand(3)
+		xs := make([]float64, n)
+		for i := range xs {
+			xs[i] = c.sample(r)
+		}
+		if d := ksStatistic(xs, c.cdf); d > ksCritical(n) {
+			t.Errorf("%s: KS distance %v exceeds %v", c.name, d, ksCritical(n))
+		}
+		mean, m2 := 0.0, 0.0
+		for _, x := range xs {
+			mean += x
+		}
+		mean /= n
+		for _, x := range xs {
+			m2 += (x - mean) * (x - mean)
+		}
+		if se := math.Sqrt(c.vari / n); math.Abs(mean-c.mean) > 6*se {
+			t.Errorf("%s: mean %v, want %v", c.name, mean, c.mean)
+		}
+		if v := m2 / (n - 1); math.Abs(v-c.vari) > 0.02 {
+			t.Errorf("%s: variance %v, want %v", c.name, v, c.vari)
+		}
+	}
+}
+
+// TestZiggurat_Tails checks the rarely taken base-layer branch against the
+// exact tail probabilities beyond the ziggurat radius.
+func TestZiggurat_Tails(t *testing.T) {
+	const n = 4_000_000
+	r := NewRand(5)
+	normTail, expTail := 0, 0
+	for range n {
+		if math.Abs(stdNormal(r)) > zigNormR {
+			normTail++
+		}
+		if stdExp(r) > zigExpR {
+			expTail++
+		}
+	}
+	check := func(name string, got int, p float64) {
+		want := n * p
+		if math.Abs(float64(got)-want) > 6*math.Sqrt(want) {
+			t.Errorf("%s: %d samples beyond R, want %v", name, got, want)
+		}
+	}
+	check("normal", normTail, math.Erfc(zigNormR/math.Sqrt2))
+	check("exp", expTail, math.Exp(-zigExpR))
+}
+
+func TestZiggurat_DrivesGammaAndChi2(t *testing.T) {
+	const n = 200000
+	for _, k := range []float64{0.5, 3, 25} {
+		d := Chi2Dist{K: k}
+		xs := make([]float64, n)
+		d.FillWith(NewRand(9), xs)
+		if ks := ksStatistic(xs, d.CDF); ks > ksCritical(n) {
+			t.Errorf("chi2(%v): KS distance %v exceeds %v", k, ks, ksCritical(n))
+		}
+	}
+}
+
+func benchSampler(b *testing.B, sample func(*rand.Rand) float64) {
+	r := NewRand(1)
+	var sink float64
+	for b.Loop() {
+		sink += sample(r)
+	}
+	_ = sink
+}
+
+func BenchmarkStdNormal_Ziggurat(b *testing.B)  { benchSampler(b, stdNormal) }
+func BenchmarkStdNormal_BoxMuller(b *testing.B) { benchSampler(b, boxMuller) }
+func BenchmarkStdExp_Ziggurat(b *testing.B)     { benchSampler(b, stdExp) }
+func BenchmarkStdExp_Inversion(b *testing.B)    { benchSampler(b, expInversion) }
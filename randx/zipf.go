@@ -0,0 +1,221 @@
+package randx
+
+import (
+	"math"
+	"math/rand/v2"
+)
+
+// ZipfDist is the finite Zipf distribution over {1, ..., N} with
+// P(X = k) proportional to k^-S.
+//
+// Most methods need the normalising constant H(N, S) = Σ k^-S, which costs
+// O(N). NewZipf and the decoders in this package compute it once and cache it
+// in the value; a struct literal, or a value whose N or S has changed since,
+// recomputes it on every call.
+type ZipfDist struct {
+	N int
+	S float64
+
+	// norm caches H(N, S) together with the N and S it was computed for.
+	norm zipfNorm
+}
+
+type zipfNorm struct {
+	n      int
+	s, sum float64
+}
+
+// NewZipf validates and returns ZipfDist{N: n, S: s} with its normalising
+// constant cached.
+func NewZipf(n int, s float64) (ZipfDist, error) {
+	d := ZipfDist{N: n, S: s}
+	if err := d.Validate(); err != nil {
+		return d, err
+	}
+	return d.precompute().(ZipfDist), nil
+}
+
+// precompute returns z with its normalising constant cached.
+func (z ZipfDist) precompute() Dist {
+	z.norm = zipfNorm{n: z.N, s: z.S, sum: harmonic(z.N, z.S)}
+	return z
+}
+
+func (z ZipfDist) Validate() error {
+	if z.N < 1 {
+		return paramError("ZipfDist", "N", float64(z.N), ">= 1")
+	}
+	if !positive(z.S) {
+		return paramError("ZipfDist", "S", z.S, "> 0")
+	}
+	return nil
+}
+
+func (z ZipfDist) Rand() float64 {
+	return z.RandWith(nil)
+}
+
+func (z ZipfDist) RandWith(r *rand.Rand) float64 {
+	if z.Validate() != nil {
+		return math.NaN()
+	}
+	return float64(newZipfSampler(z.N, z.S).sample(r))
+}
+
+// harmonic returns the generalized harmonic number Σ_{k=1}^{n} k^-s.
+func harmonic(n int, s float64) float64 {
+	sum := 0.0
+	for k := n; k >= 1; k-- {
+		sum += math.Pow(float64(k), -s)
+	}
+	return sum
+}
+
+// total returns H(N, S), from the cache when it matches N and S.
+func (z ZipfDist) total() float64 {
+	if z.norm.n == z.N && z.norm.s == z.S && z.norm.sum > 0 {
+		return z.norm.sum
+	}
+	return harmonic(z.N, z.S)
+}
+
+func (z ZipfDist) PDF(x float64) float64 {
+	if z.Validate() != nil {
+		return math.NaN()
+	}
+	k := int(math.Round(x))
+	if float64(k) != x || k < 1 || k > z.N {
+		return 0
+	}
+	return math.Pow(float64(k), -z.S) / z.total()
+}
+
+func (z ZipfDist) CDF(x float64) float64 {
+	if z.Validate() != nil || math.IsNaN(x) {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 1 {
+		return 0
+	}
+	if k >= float64(z.N) {
+		return 1
+	}
+	return harmonic(int(k), z.S) / z.total()
+}
+
+func (z ZipfDist) Quantile(q float64) float64 {
+	if z.Validate() != nil || math.IsNaN(q) || q < 0 || q > 1 {
+		return math.NaN()
+	}
+	total := z.total()
+	sum := 0.0
+	for k := 1; k < z.N; k++ {
+		sum += math.Pow(float64(k), -z.S)
+		if sum/total >= q {
+			return float64(k)
+		}
+	}
+	return float64(z.N)
+}
+
+// rawMoment returns E[X^m] = H(N, S-m) / H(N, S).
+func (z ZipfDist) rawMoment(m float64) float64 {
+	return harmonic(z.N, z.S-m) / z.total()
+}
+
+func (z ZipfDist) Mean() float64 {
+	if z.Validate() != nil {
+		return math.NaN()
+	}
+	return z.rawMoment(1)
+}
+
+func (z ZipfDist) Variance() float64 {
+	if z.Validate() != nil {
+		return math.NaN()
+	}
+	m1 := z.rawMoment(1)
+	return z.rawMoment(2) - m1*m1
+}
+
+func (z ZipfDist) StdDev() float64 {
+	return math.Sqrt(z.Variance())
+}
+
+func (z ZipfDist) Skewness() float64 {
+	if z.Validate() != nil || z.N < 2 {
+		return math.NaN()
+	}
+	m1, m2, m3 := z.rawMoment(1), z.rawMoment(2), z.rawMoment(3)
+	v := m2 - m1*m1
+	return (m3 - 3*m1*v - m1*m1*m1) / math.Pow(v, 1.5)
+}
+
+func (z ZipfDist) ExKurtosis() float64 {
+	if z.Validate() != nil || z.N < 2 {
+		return math.NaN()
+	}
+	m1, m2, m3, m4 := z.rawMoment(1), z.rawMoment(2), z.rawMoment(3), z.rawMoment(4)
+	v := m2 - m1*m1
+	c4 := m4 - 4*m1*m3 + 6*m1*m1*m2 - 3*m1*m1*m1*m1
+	return c4/(v*v) - 3
+}
+
+func (z ZipfDist) Mode() float64 {
+	if z.Validate() != nil {
+		return math.NaN()
+	}
+	return 1
+}
+
+func (z ZipfDist) Median() float64 {
+	return z.Quantile(0.5)
+}
+
+func (z ZipfDist) Entropy() float64 {
+	if z.Validate() != nil {
+		return math.NaN()
+	}
+	total := z.total()
+	pmf := func(k int) float64 { return math.Pow(float64(k), -z.S) / total }
+	return discreteEntropy(pmf, 1, z.N)
+}
+
+func (z ZipfDist) LogPDF(x float64) float64 {
+	if z.Validate() != nil {
+		return math.NaN()
+	}
+	k := int(math.Round(x))
+	if float64(k) != x || k < 1 || k > z.N {
+		return math.Inf(-1)
+	}
+	return -z.S*math.Log(float64(k)) - math.Log(z.total())
+}
+
+func (z ZipfDist) LogCDF(x float64) float64 {
+	return math.Log(z.CDF(x))
+}
+
+// Survival sums the tail k+1..N directly, smallest terms first.
+func (z ZipfDist) Survival(x float64) float64 {
+	if z.Validate() != nil || math.IsNaN(x) {
+		return math.NaN()
+	}
+	k := math.Floor(x)
+	if k < 1 {
+		return 1
+	}
+	if k >= float64(z.N) {
+		return 0
+	}
+	sum := 0.0
+	for i := z.N; i > int(k); i-- {
+		sum += math.Pow(float64(i), -z.S)
+	}
+	return sum / z.total()
+}
+
+func (z ZipfDist) LogSurvival(x float64) float64 {
+	return math.Log(z.Survival(x))
+}